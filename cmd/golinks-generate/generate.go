@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-golinks/internal/client"
+	"terraform-provider-golinks/internal/ownership"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	groupByNone  = "none"
+	groupByTag   = "tag"
+	groupByOwner = "owner"
+)
+
+var invalidIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// identifier turns s into a valid, lowercase Terraform identifier.
+func identifier(s string) string {
+	id := invalidIdentifierChars.ReplaceAllString(strings.ToLower(s), "_")
+	id = strings.Trim(id, "_")
+	if id == "" {
+		return "link"
+	}
+	if id[0] >= '0' && id[0] <= '9' {
+		id = "link_" + id
+	}
+	return id
+}
+
+// tagFilter matches the tags that golinks_link does not read back: ownership
+// markers and the tags of the provider ignore_tags block.
+type tagFilter struct {
+	Names    []string
+	Prefixes []string
+}
+
+// ignores reports whether tag must be left out of the generated configuration.
+func (f tagFilter) ignores(tag string) bool {
+	if ownership.IsMarkerTag(tag) || slices.Contains(f.Names, tag) {
+		return true
+	}
+	for _, prefix := range f.Prefixes {
+		if strings.HasPrefix(tag, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// configurable returns link with the tags and the description footer that
+// golinks_link does not read back removed, so that the generated
+// configuration plans no changes.
+func configurable(link client.GolinkResponse, ignore tagFilter) client.GolinkResponse {
+	tags := make([]client.TagResponse, 0, len(link.Tags))
+	for _, tag := range link.Tags {
+		if !ignore.ignores(tag.Name) {
+			tags = append(tags, tag)
+		}
+	}
+	link.Tags = tags
+	link.Description = ownership.StripFooter(link.Description)
+	return link
+}
+
+// resourceNames assigns every link a unique resource name derived from its
+// link name. Links are visited in gid order so that collisions are always
+// resolved the same way, the oldest link keeping the undecorated name.
+func resourceNames(links []client.GolinkResponse) map[int64]string {
+	sorted := make([]client.GolinkResponse, len(links))
+	copy(sorted, links)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Gid < sorted[j].Gid
+	})
+
+	names := make(map[int64]string, len(sorted))
+	used := make(map[string]bool, len(sorted))
+	for _, link := range sorted {
+		base := identifier(link.Name)
+		name := base
+		for i := 2; used[name]; i++ {
+			name = base + "_" + strconv.Itoa(i)
+		}
+		used[name] = true
+		names[link.Gid] = name
+	}
+	return names
+}
+
+// groupName returns the name of the file group that link belongs to.
+func groupName(link client.GolinkResponse, groupBy string) string {
+	switch groupBy {
+	case groupByTag:
+		if len(link.Tags) == 0 {
+			return "untagged"
+		}
+		tags := make([]string, 0, len(link.Tags))
+		for _, tag := range link.Tags {
+			tags = append(tags, tag.Name)
+		}
+		sort.Strings(tags)
+		return identifier(tags[0])
+	case groupByOwner:
+		if link.User.Email != "" {
+			local, _, _ := strings.Cut(link.User.Email, "@")
+			return identifier(local)
+		}
+		if link.User.Username != "" {
+			return identifier(link.User.Username)
+		}
+		return "unowned"
+	default:
+		return "links"
+	}
+}
+
+// generate renders the resource and import blocks of links, keyed by the file
+// name they should be written to. When namePrefix is set, only the links whose
+// name starts with it are rendered, with the prefix stripped from their name
+// and aliases to match the provider name_prefix setting. Tags matched by
+// ignore are left out.
+func generate(links []client.GolinkResponse, groupBy, namePrefix string, ignore tagFilter) map[string][]byte {
+	cleaned := make([]client.GolinkResponse, 0, len(links))
+	for _, link := range links {
+		cleaned = append(cleaned, configurable(link, ignore))
+	}
+	links = cleaned
+
+	if namePrefix != "" {
+		var namespaced []client.GolinkResponse
+		for _, link := range links {
+			if name, ok := strings.CutPrefix(link.Name, namePrefix); ok {
+				link.Name = name
+				link.Aliases = slices.Clone(link.Aliases)
+				for i, alias := range link.Aliases {
+					link.Aliases[i] = strings.TrimPrefix(alias, namePrefix)
+				}
+				namespaced = append(namespaced, link)
+			}
+		}
+		links = namespaced
+	}
+
+	names := resourceNames(links)
+
+	groups := map[string][]client.GolinkResponse{}
+	for _, link := range links {
+		group := groupName(link, groupBy)
+		groups[group] = append(groups[group], link)
+	}
+
+	files := make(map[string][]byte, len(groups))
+	for group, groupLinks := range groups {
+		sort.Slice(groupLinks, func(i, j int) bool {
+			return names[groupLinks[i].Gid] < names[groupLinks[j].Gid]
+		})
+
+		f := hclwrite.NewEmptyFile()
+		body := f.Body()
+		for i, link := range groupLinks {
+			if i > 0 {
+				body.AppendNewline()
+			}
+			writeLink(body, names[link.Gid], link)
+		}
+		files[group+".tf"] = f.Bytes()
+	}
+	return files
+}
+
+// writeLink appends the import and resource blocks of a single link. Every
+// attribute golinks_link reads back is written, so that importing the link
+// plans no changes.
+func writeLink(body *hclwrite.Body, name string, link client.GolinkResponse) {
+	importBody := body.AppendNewBlock("import", nil).Body()
+	importBody.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: "golinks_link"},
+		hcl.TraverseAttr{Name: name},
+	})
+	importBody.SetAttributeValue("id", cty.StringVal(strconv.FormatInt(link.Gid, 10)))
+	body.AppendNewline()
+
+	resourceBody := body.AppendNewBlock("resource", []string{"golinks_link", name}).Body()
+	resourceBody.SetAttributeValue("name", cty.StringVal(link.Name))
+	resourceBody.SetAttributeValue("url", cty.StringVal(link.URL))
+	resourceBody.SetAttributeValue("description", cty.StringVal(link.Description))
+	flags := []struct {
+		name  string
+		value int32
+	}{
+		{"unlisted", link.Unlisted},
+		{"private", link.Private},
+		{"public", link.Public},
+		{"format", link.Format},
+		{"hyphens", link.Hyphens},
+	}
+	for _, flag := range flags {
+		if flag.value == 1 {
+			resourceBody.SetAttributeValue(flag.name, cty.True)
+		}
+	}
+	if len(link.Tags) > 0 {
+		tags := make([]cty.Value, 0, len(link.Tags))
+		for _, tag := range link.Tags {
+			tags = append(tags, cty.StringVal(tag.Name))
+		}
+		resourceBody.SetAttributeValue("tags", cty.ListVal(tags))
+	}
+	if len(link.Aliases) > 0 {
+		aliases := make([]cty.Value, 0, len(link.Aliases))
+		for _, alias := range link.Aliases {
+			aliases = append(aliases, cty.StringVal(alias))
+		}
+		resourceBody.SetAttributeValue("aliases", cty.ListVal(aliases))
+	}
+	if len(link.Geolinks) > 0 {
+		geolinks := make([]cty.Value, 0, len(link.Geolinks))
+		for _, geolink := range link.Geolinks {
+			geolinks = append(geolinks, cty.ObjectVal(map[string]cty.Value{
+				"location": cty.StringVal(geolink.Location),
+				"url":      cty.StringVal(geolink.URL),
+			}))
+		}
+		resourceBody.SetAttributeValue("geolinks", cty.ListVal(geolinks))
+	}
+}
+
+// writeFiles writes the generated files to dir, creating it if needed.
+func writeFiles(dir string, files map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"testing"
+
+	"terraform-provider-golinks/internal/client"
+)
+
+func TestResourceNames(t *testing.T) {
+	links := []client.GolinkResponse{
+		{Gid: 3, Name: "wiki"},
+		{Gid: 1, Name: "Wiki!"},
+		{Gid: 2, Name: "2fa-setup"},
+		{Gid: 4, Name: "..."},
+	}
+
+	names := resourceNames(links)
+
+	expected := map[int64]string{
+		1: "wiki",
+		2: "link_2fa_setup",
+		3: "wiki_2",
+		4: "link",
+	}
+	for gid, name := range expected {
+		if names[gid] != name {
+			t.Errorf("gid %d: expected %q, got %q", gid, name, names[gid])
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	links := []client.GolinkResponse{
+		{
+			Gid:         10,
+			Name:        "wiki",
+			URL:         "https://wiki.example.com",
+			Description: "Team wiki",
+			Tags:        []client.TagResponse{{Name: "eng"}},
+			User:        client.UserResponse{Email: "jane@example.com"},
+		},
+		{
+			Gid:         11,
+			Name:        "deck",
+			URL:         "https://deck.example.com",
+			Description: "Sales deck",
+			Unlisted:    1,
+		},
+	}
+
+	files := generate(links, groupByTag, "", tagFilter{})
+
+	expectedEng := `import {
+  to = golinks_link.wiki
+  id = "10"
+}
+
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Team wiki"
+  tags        = ["eng"]
+}
+`
+	if got := string(files["eng.tf"]); got != expectedEng {
+		t.Errorf("unexpected eng.tf:\n%s", got)
+	}
+
+	expectedUntagged := `import {
+  to = golinks_link.deck
+  id = "11"
+}
+
+resource "golinks_link" "deck" {
+  name        = "deck"
+  url         = "https://deck.example.com"
+  description = "Sales deck"
+  unlisted    = true
+}
+`
+	if got := string(files["untagged.tf"]); got != expectedUntagged {
+		t.Errorf("unexpected untagged.tf:\n%s", got)
+	}
+
+	if _, ok := generate(links, groupByOwner, "", tagFilter{})["jane.tf"]; !ok {
+		t.Errorf("expected links to be grouped by owner")
+	}
+}
+
+func TestGenerateNamePrefix(t *testing.T) {
+	links := []client.GolinkResponse{
+		{Gid: 10, Name: "payments/wiki", URL: "https://wiki.example.com", Description: "Payments wiki", Aliases: []string{"payments/kb"}},
+		{Gid: 11, Name: "sales/deck", URL: "https://deck.example.com", Description: "Sales deck"},
+	}
+
+	files := generate(links, groupByNone, "payments/", tagFilter{})
+
+	expected := `import {
+  to = golinks_link.wiki
+  id = "10"
+}
+
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Payments wiki"
+  aliases     = ["kb"]
+}
+`
+	if got := string(files["links.tf"]); got != expected {
+		t.Errorf("unexpected links.tf:\n%s", got)
+	}
+}
+
+func TestGenerateAllAttributes(t *testing.T) {
+	links := []client.GolinkResponse{
+		{
+			Gid:         10,
+			Name:        "payroll",
+			URL:         "https://payroll.example.com",
+			Description: "Payroll",
+			Unlisted:    1,
+			Private:     1,
+			Public:      1,
+			Format:      1,
+			Hyphens:     1,
+			Aliases:     []string{"pay"},
+			Geolinks:    []client.Geolink{{Location: "FR", URL: "https://payroll.example.fr"}},
+		},
+	}
+
+	files := generate(links, groupByNone, "", tagFilter{})
+
+	expected := `import {
+  to = golinks_link.payroll
+  id = "10"
+}
+
+resource "golinks_link" "payroll" {
+  name        = "payroll"
+  url         = "https://payroll.example.com"
+  description = "Payroll"
+  unlisted    = true
+  private     = true
+  public      = true
+  format      = true
+  hyphens     = true
+  aliases     = ["pay"]
+  geolinks = [{
+    location = "FR"
+    url      = "https://payroll.example.fr"
+  }]
+}
+`
+	if got := string(files["links.tf"]); got != expected {
+		t.Errorf("unexpected links.tf:\n%s", got)
+	}
+}
+
+func TestGenerateIgnoredTags(t *testing.T) {
+	links := []client.GolinkResponse{
+		{
+			Gid:         10,
+			Name:        "wiki",
+			URL:         "https://wiki.example.com",
+			Description: "Team wiki\n\nManaged by Terraform workspace payments-prod",
+			Tags: []client.TagResponse{
+				{Name: "managed-by:payments-prod"},
+				{Name: "reviewed"},
+				{Name: "admin:pinned"},
+				{Name: "eng"},
+			},
+		},
+	}
+
+	files := generate(links, groupByTag, "", tagFilter{Names: []string{"reviewed"}, Prefixes: []string{"admin:"}})
+
+	expected := `import {
+  to = golinks_link.wiki
+  id = "10"
+}
+
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Team wiki"
+  tags        = ["eng"]
+}
+`
+	if got := string(files["eng.tf"]); got != expected {
+		t.Errorf("unexpected eng.tf:\n%s", got)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Command golinks-generate writes golinks_link resource blocks and matching
+// import blocks for every existing GoLink, so that links created by hand can
+// be brought under Terraform management.
+//
+// Usage:
+//
+//	GOLINKS_TOKEN=... go run ./cmd/golinks-generate -out ./links -group-by tag
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"terraform-provider-golinks/internal/client"
+)
+
+func main() {
+	var (
+		host       string
+		out        string
+		groupBy    string
+		namePrefix string
+		ignore     tagFilter
+	)
+
+	flag.StringVar(&host, "host", os.Getenv("GOLINKS_HOST"), "URL of the GoLinks API")
+	flag.StringVar(&out, "out", ".", "directory to write the generated configuration to")
+	flag.StringVar(&groupBy, "group-by", groupByNone, "group links into files by \"tag\", \"owner\" or \"none\"")
+	flag.StringVar(&namePrefix, "name-prefix", "", "only generate links whose name starts with this prefix, stripping it like the provider name_prefix")
+	flag.Func("ignore-tags", "comma-separated tags to leave out, like the provider ignore_tags names", func(v string) error {
+		ignore.Names = append(ignore.Names, splitList(v)...)
+		return nil
+	})
+	flag.Func("ignore-tag-prefixes", "comma-separated tag prefixes to leave out, like the provider ignore_tags name_prefixes", func(v string) error {
+		ignore.Prefixes = append(ignore.Prefixes, splitList(v)...)
+		return nil
+	})
+	flag.Parse()
+
+	switch groupBy {
+	case groupByNone, groupByTag, groupByOwner:
+	default:
+		log.Fatalf("invalid -group-by %q, expected \"tag\", \"owner\" or \"none\"", groupBy)
+	}
+
+	token := os.Getenv("GOLINKS_TOKEN")
+	if token == "" {
+		log.Fatal("GOLINKS_TOKEN must be set")
+	}
+
+	ctx := context.Background()
+	c, err := client.NewClient(ctx, &host, &token)
+	if err != nil {
+		log.Fatalf("creating GoLinks client: %s", err)
+	}
+
+	links, err := c.GetAllGolinks(ctx)
+	if err != nil {
+		log.Fatalf("listing links: %s", err)
+	}
+
+	files := generate(links, groupBy, namePrefix, ignore)
+	if err := writeFiles(out, files); err != nil {
+		log.Fatal(err)
+	}
+
+	count := 0
+	for _, link := range links {
+		if strings.HasPrefix(link.Name, namePrefix) {
+			count++
+		}
+	}
+	fmt.Printf("Wrote %d links to %d files in %s\n", count, len(files), out)
+}
+
+// splitList splits a comma-separated flag value, dropping empty elements.
+func splitList(v string) []string {
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,19 @@
+resource "golinks_link" "incident" {
+  name        = "incident"
+  url         = "https://status.example.com"
+  description = "Current incident status page"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.golinks_pin.incident]
+    }
+  }
+}
+
+action "golinks_pin" "incident" {
+  config {
+    gid      = golinks_link.incident.gid
+    duration = "168h"
+  }
+}
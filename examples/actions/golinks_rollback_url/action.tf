@@ -0,0 +1,6 @@
+# Invoke with: terraform apply -invoke=action.golinks_rollback_url.wiki
+action "golinks_rollback_url" "wiki" {
+  config {
+    name = "wiki"
+  }
+}
@@ -0,0 +1,20 @@
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Team wiki"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.golinks_verify_destination.wiki]
+    }
+  }
+}
+
+action "golinks_verify_destination" "wiki" {
+  config {
+    gid             = golinks_link.wiki.gid
+    expected_status = [200]
+    timeout         = "30s"
+  }
+}
@@ -0,0 +1,5 @@
+data "golinks_link_analytics" "wiki" {
+  names      = ["wiki", "docs"]
+  start_date = "2025-01-01"
+  end_date   = "2025-01-31"
+}
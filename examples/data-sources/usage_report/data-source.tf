@@ -0,0 +1,5 @@
+data "golinks_usage_report" "quarterly" {
+  top_n                  = 20
+  monthly_hits_threshold = 5
+  stale_since            = "2024-01-01"
+}
@@ -0,0 +1,4 @@
+output "ticket" {
+  # Returns "https://jira.example.com/browse/ENG-123".
+  value = provider::golinks::expand_variable_link("https://jira.example.com/browse/%s", ["ENG-123"])
+}
@@ -0,0 +1,9 @@
+output "wiki_url" {
+  # Returns "https://go/wiki".
+  value = provider::golinks::go_url("wiki", false)
+}
+
+output "ticket_url" {
+  # Returns "https://golinks.io/jira/ENG-123".
+  value = provider::golinks::go_url("jira", true, "ENG-123")
+}
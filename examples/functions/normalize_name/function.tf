@@ -0,0 +1,4 @@
+output "normalized_name" {
+  # Returns "team-wiki".
+  value = provider::golinks::normalize_name("team wiki!", true)
+}
@@ -0,0 +1,4 @@
+output "parsed" {
+  # Returns { name = "jira", path = ["ENG", "123"] }.
+  value = provider::golinks::parse_go_url("https://go/jira/ENG/123")
+}
@@ -0,0 +1,9 @@
+list "golinks_link" "engineering" {
+  provider         = golinks
+  include_resource = true
+
+  config {
+    tag         = "engineering"
+    name_prefix = "eng-"
+  }
+}
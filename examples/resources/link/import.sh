@@ -1 +1,6 @@
 terraform import golinks_link.example 675145
+
+# A link can also be imported by name, by alias or by its go link.
+terraform import golinks_link.example name:wiki
+terraform import golinks_link.example alias:docs
+terraform import golinks_link.example go/wiki
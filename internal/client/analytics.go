@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// GetLinkAnalytics retrieves the per-day redirect hits of a link between
+// startDate and endDate, both inclusive and formatted as YYYY-MM-DD.
+func (c *Client) GetLinkAnalytics(ctx context.Context, gid int64, startDate, endDate string) (*AnalyticsResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/analytics", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	query.Set("gid", strconv.FormatInt(gid, 10))
+	query.Set("start_date", startDate)
+	query.Set("end_date", endDate)
+	req.URL.RawQuery = query.Encode()
+
+	var resp AnalyticsResponse
+	if err := c.doRequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
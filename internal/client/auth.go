@@ -10,6 +10,12 @@ import (
 )
 
 func (c *Client) SignIn(ctx context.Context) (*AuthResponse, error) {
+	return c.signIn(ctx, http.MethodGet)
+}
+
+// signIn validates the token, retrying transient failures as a request with
+// the given method would be.
+func (c *Client) signIn(ctx context.Context, method string) (*AuthResponse, error) {
 	if c.Auth.Token == "" {
 		return nil, fmt.Errorf("token is required")
 	}
@@ -21,7 +27,7 @@ func (c *Client) SignIn(ctx context.Context) (*AuthResponse, error) {
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Auth.Token))
 
-	_, err = c.doRequest(req)
+	_, err = c.sendWithRetries(req, method)
 	if err != nil {
 		return nil, err
 	}
@@ -6,18 +6,33 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	HostURL                = "https://api.golinks.io"
 	contentTypeFormEncoded = "application/x-www-form-urlencoded"
+	defaultPageSize        = 50
+
+	// defaultRequestTimeout bounds a single attempt when the caller did not
+	// set a deadline on the request context.
+	defaultRequestTimeout = 30 * time.Second
+
+	// maxRetries is the number of times a request is retried when the API
+	// is rate limiting or temporarily unavailable.
+	maxRetries = 4
+
+	// retryWaitMin is the wait before the first retry. It doubles on every
+	// retry.
+	retryWaitMin = 250 * time.Millisecond
 )
 
 type Client struct {
@@ -25,36 +40,117 @@ type Client struct {
 	HTTPClient *http.Client
 	Auth       AuthStruct
 	Token      string
+
+	// ReadOnly makes the client refuse every request that could change
+	// links, failing it with ErrReadOnly.
+	ReadOnly bool
+
+	// validateCredentials makes the first request sign in before it is sent.
+	validateCredentials bool
+	signInMu            sync.Mutex
+	signedIn            bool
+}
+
+// NewClient creates a client and validates the token by signing in.
+func NewClient(ctx context.Context, host, token *string) (*Client, error) {
+	c, err := newClient(host, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ar, err := c.SignIn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Token = ar.Token
+
+	return c, nil
+}
+
+// NewLazyClient creates a client without contacting the API. Unless
+// skipCredentialsValidation is set, the token is validated by signing in
+// before the first request is sent.
+func NewLazyClient(host, token *string, skipCredentialsValidation bool) (*Client, error) {
+	c, err := newClient(host, token)
+	if err != nil {
+		return nil, err
+	}
+
+	c.validateCredentials = !skipCredentialsValidation
+
+	return c, nil
 }
 
-func NewClient(ctx context.Context, token *string) (*Client, error) {
+func newClient(host, token *string) (*Client, error) {
 	if token == nil {
 		return nil, fmt.Errorf("token is required")
 	}
 
 	c := Client{
-		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		HTTPClient: &http.Client{},
 		HostURL:    HostURL,
 		Token:      *token,
 		Auth:       AuthStruct{Token: *token},
 	}
 
-	ar, err := c.SignIn(ctx)
-	if err != nil {
-		return nil, err
+	if host != nil && *host != "" {
+		c.HostURL = strings.TrimSuffix(*host, "/")
+	}
+
+	return &c, nil
+}
+
+// signInLazily signs in once for a client created by NewLazyClient. Only a
+// successful sign-in is remembered, so a failure is retried by the next
+// request. The sign-in is not bound by the deadline of the request that
+// triggered it, which may be too short for both calls, but its transient
+// failures are retried following the rules of that request's method. The
+// error does not wrap the API error, so that a failed sign-in is never
+// mistaken for a missing object by IsNotFound.
+func (c *Client) signInLazily(ctx context.Context, method string) error {
+	if !c.validateCredentials {
+		return nil
 	}
 
+	c.signInMu.Lock()
+	defer c.signInMu.Unlock()
+
+	if c.signedIn {
+		return nil
+	}
+
+	ar, err := c.signIn(context.WithoutCancel(ctx), method)
+	if err != nil {
+		return fmt.Errorf("unable to validate credentials: %s", err)
+	}
 	c.Token = ar.Token
+	c.signedIn = true
+	return nil
+}
 
-	return &c, nil
+// GolinksQuery selects a page of links. Zero values fall back to the API
+// defaults.
+type GolinksQuery struct {
+	Limit  int64
+	Offset int64
 }
 
-func (c *Client) GetGolinks(ctx context.Context) (*GolinksResponse, error) {
+func (c *Client) GetGolinks(ctx context.Context, query GolinksQuery) (*GolinksResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/golinks", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
 
+	values := req.URL.Query()
+	if query.Limit > 0 {
+		values.Set("limit", strconv.FormatInt(query.Limit, 10))
+	}
+	if query.Offset > 0 {
+		values.Set("offset", strconv.FormatInt(query.Offset, 10))
+	}
+	req.URL.RawQuery = values.Encode()
+
 	var resp GolinksResponse
 	if err := c.doRequestJSON(req, &resp); err != nil {
 		return nil, err
@@ -62,6 +158,24 @@ func (c *Client) GetGolinks(ctx context.Context) (*GolinksResponse, error) {
 	return &resp, nil
 }
 
+// GetAllGolinks pages through GetGolinks until every link has been returned.
+func (c *Client) GetAllGolinks(ctx context.Context) ([]GolinkResponse, error) {
+	var links []GolinkResponse
+	query := GolinksQuery{Limit: defaultPageSize}
+	for {
+		resp, err := c.GetGolinks(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, resp.Results...)
+
+		query.Offset += int64(len(resp.Results))
+		if len(resp.Results) == 0 || query.Offset >= resp.Metadata.TotalResults {
+			return links, nil
+		}
+	}
+}
+
 func (c *Client) GetGolinksByName(ctx context.Context, name string) (*GolinkResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/golinks", c.HostURL), nil)
 	if err != nil {
@@ -79,6 +193,24 @@ func (c *Client) GetGolinksByName(ctx context.Context, name string) (*GolinkResp
 	return &resp, nil
 }
 
+// GetGolinksByAlias retrieves the link that the given alias points to.
+func (c *Client) GetGolinksByAlias(ctx context.Context, alias string) (*GolinkResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/golinks", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	query.Set("alias", alias)
+	req.URL.RawQuery = query.Encode()
+
+	var resp GolinkResponse
+	if err := c.doRequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func buildCreateLinkFormData(link CreateLinkRequest) url.Values {
 	formData := url.Values{}
 	formData.Set("name", link.Name)
@@ -103,6 +235,9 @@ func buildCreateLinkFormData(link CreateLinkRequest) url.Values {
 		formData.Set(fmt.Sprintf("geolinks[%d][location]", i), geo.Location)
 		formData.Set(fmt.Sprintf("geolinks[%d][url]", i), geo.URL)
 	}
+	if link.UID != 0 {
+		formData.Set("uid", strconv.FormatInt(link.UID, 10))
+	}
 	return formData
 }
 
@@ -129,6 +264,9 @@ func buildUpdateLinkFormData(link UpdateLinkRequest) url.Values {
 		formData.Set(fmt.Sprintf("geolinks[%d][location]", i), geo.Location)
 		formData.Set(fmt.Sprintf("geolinks[%d][url]", i), geo.URL)
 	}
+	if link.UID != 0 {
+		formData.Set("uid", strconv.FormatInt(link.UID, 10))
+	}
 	return formData
 }
 
@@ -191,7 +329,100 @@ func (c *Client) GetLink(ctx context.Context, gid string) (*GolinkResponse, erro
 	return &resp, nil
 }
 
+// ErrReadOnly is returned for requests that could change links when the
+// client is read-only.
+var ErrReadOnly = errors.New("the GoLinks client is read-only")
+
+// APIError is returned when the API responds with a non-2xx status code.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an API error for a missing object.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	if c.ReadOnly && req.Method != http.MethodGet {
+		return nil, fmt.Errorf("%w: refusing %s %s", ErrReadOnly, req.Method, req.URL.Path)
+	}
+
+	if err := c.signInLazily(req.Context(), req.Method); err != nil {
+		return nil, err
+	}
+
+	return c.sendWithRetries(req, req.Method)
+}
+
+// sendWithRetries sends req, retrying while the API is rate limiting or
+// temporarily unavailable, until maxRetries or the deadline of the request
+// context is reached. Failures are retried following the rules of method: a
+// POST is only retried when the API rejected it with 429, as any other
+// failure may have come after the link was created.
+func (c *Client) sendWithRetries(req *http.Request, method string) ([]byte, error) {
+	ctx := req.Context()
+	wait := retryWaitMin
+	for retry := 0; ; retry++ {
+		attempt := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+
+		body, err := c.send(attempt)
+		if err == nil || retry == maxRetries || !retryable(method, err) || ctx.Err() != nil {
+			return body, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w (last error: %s)", ctx.Err(), err)
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// retryable reports whether a request with the given method that failed
+// with err may be sent again.
+func retryable(method string, err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// The request may have reached the API before the connection
+		// failed.
+		return method != http.MethodPost
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return method != http.MethodPost
+	default:
+		return false
+	}
+}
+
+// send performs a single attempt of req without signing in first. The
+// attempt is bounded by defaultRequestTimeout unless the request context
+// already has a deadline.
+func (c *Client) send(req *http.Request) ([]byte, error) {
+	if _, ok := req.Context().Deadline(); !ok {
+		ctx, cancel := context.WithTimeout(req.Context(), defaultRequestTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
 
 	res, err := c.HTTPClient.Do(req)
@@ -206,7 +437,7 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 	}
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, &APIError{StatusCode: res.StatusCode, Body: string(body)}
 	}
 
 	return body, err
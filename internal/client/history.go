@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// GetLinkHistory retrieves the previous revisions of a link, most recent
+// first.
+func (c *Client) GetLinkHistory(ctx context.Context, gid int64) (*LinkHistoryResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/golinks/history", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	query.Set("gid", strconv.FormatInt(gid, 10))
+	req.URL.RawQuery = query.Encode()
+
+	var resp LinkHistoryResponse
+	if err := c.doRequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PinLink pins a link to the top of the company's link list. The pin expires
+// at the given Unix timestamp, or never when expiresAt is zero.
+func (c *Client) PinLink(ctx context.Context, gid int64, expiresAt int64) (*GolinkResponse, error) {
+	formData := url.Values{}
+	formData.Set("gid", strconv.FormatInt(gid, 10))
+	if expiresAt != 0 {
+		formData.Set("expires_at", strconv.FormatInt(expiresAt, 10))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/golinks/pin", c.HostURL), strings.NewReader(formData.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", contentTypeFormEncoded)
+
+	var resp GolinkResponse
+	if err := c.doRequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -15,6 +15,7 @@ type CreateLinkRequest struct {
 	Hyphens     int32     `json:"hyphens,omitempty"`
 	Aliases     []string  `json:"aliases,omitempty"`
 	Geolinks    []Geolink `json:"geolinks,omitempty"`
+	UID         int64     `json:"uid,omitempty"`
 }
 
 type UpdateLinkRequest struct {
@@ -30,6 +31,7 @@ type UpdateLinkRequest struct {
 	Hyphens     int32     `json:"hyphens,omitempty"`
 	Aliases     []string  `json:"aliases,omitempty"`
 	Geolinks    []Geolink `json:"geolinks,omitempty"`
+	UID         int64     `json:"uid,omitempty"`
 }
 
 type Geolink struct {
@@ -74,6 +76,10 @@ type GolinkResponse struct {
 	Description  string               `json:"description"`
 	Tags         []TagResponse        `json:"tags"`
 	Unlisted     int32                `json:"unlisted"`
+	Public       int32                `json:"public"`
+	Private      int32                `json:"private"`
+	Aliases      []string             `json:"aliases"`
+	Geolinks     []Geolink            `json:"geolinks"`
 	VariableLink int32                `json:"variable_link"`
 	Pinned       int32                `json:"pinned"`
 	Format       int32                `json:"format"`
@@ -92,6 +98,11 @@ type UserResponse struct {
 	UserImageURL string `json:"user_image_url"`
 }
 
+type UsersResponse struct {
+	Metadata MetadataResponse `json:"metadata"`
+	Results  []UserResponse   `json:"results"`
+}
+
 type TagResponse struct {
 	Tid  int64  `json:"tid"`
 	Name string `json:"name"`
@@ -103,3 +114,27 @@ type RedirectHitsResponse struct {
 	Monthly int64 `json:"monthly"`
 	Alltime int64 `json:"alltime"`
 }
+
+type AnalyticsResponse struct {
+	Gid       int64               `json:"gid"`
+	StartDate string              `json:"start_date"`
+	EndDate   string              `json:"end_date"`
+	Series    []DailyHitsResponse `json:"series"`
+}
+
+type DailyHitsResponse struct {
+	Date string `json:"date"`
+	Hits int64  `json:"hits"`
+}
+
+type LinkHistoryResponse struct {
+	Gid     int64                  `json:"gid"`
+	Results []LinkRevisionResponse `json:"results"`
+}
+
+type LinkRevisionResponse struct {
+	URL         string       `json:"url"`
+	Description string       `json:"description"`
+	User        UserResponse `json:"user"`
+	UpdatedAt   int64        `json:"updated_at"`
+}
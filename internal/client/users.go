@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetUser retrieves a single user by uid.
+func (c *Client) GetUser(ctx context.Context, uid int64) (*UserResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/users/%d", c.HostURL, uid), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp UserResponse
+	if err := c.doRequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetUserByEmail retrieves the user registered with the given email address.
+func (c *Client) GetUserByEmail(ctx context.Context, email string) (*UserResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/users", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	query.Set("email", email)
+	req.URL.RawQuery = query.Encode()
+
+	var resp UsersResponse
+	if err := c.doRequestJSON(req, &resp); err != nil {
+		return nil, err
+	}
+
+	for i := range resp.Results {
+		if strings.EqualFold(resp.Results[i].Email, email) {
+			return &resp.Results[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no user found with email %q", email)
+}
+
+// LookupUser resolves an owner reference, either a numeric uid or an email
+// address, to a user.
+func (c *Client) LookupUser(ctx context.Context, owner string) (*UserResponse, error) {
+	if uid, err := strconv.ParseInt(owner, 10, 64); err == nil {
+		return c.GetUser(ctx, uid)
+	}
+	return c.GetUserByEmail(ctx, owner)
+}
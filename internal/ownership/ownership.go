@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ownership recognizes the markers that stamp a link with the
+// Terraform workspace managing it. It is shared by the provider and the
+// golinks-generate command, which must leave the markers out of the
+// configuration it generates.
+package ownership
+
+import "strings"
+
+const (
+	// TagPrefix starts the reserved ownership tag.
+	TagPrefix = "managed-by:"
+	// FooterPrefix starts the ownership description footer.
+	FooterPrefix = "\n\nManaged by Terraform workspace "
+)
+
+// IsMarkerTag reports whether tag is the ownership marker of any workspace.
+func IsMarkerTag(tag string) bool {
+	return strings.HasPrefix(tag, TagPrefix)
+}
+
+// FooterWorkspace returns the workspace named by the ownership footer of
+// description, if it has one.
+func FooterWorkspace(description string) (string, bool) {
+	i := strings.LastIndex(description, FooterPrefix)
+	if i < 0 {
+		return "", false
+	}
+	workspace := description[i+len(FooterPrefix):]
+	if strings.Contains(workspace, "\n") {
+		return "", false
+	}
+	return workspace, true
+}
+
+// StripFooter removes the ownership footer of any workspace from
+// description.
+func StripFooter(description string) string {
+	if workspace, ok := FooterWorkspace(description); ok {
+		return strings.TrimSuffix(description, FooterPrefix+workspace)
+	}
+	return description
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// variableLinkArgument is replaced by the next argument of a variable link.
+	variableLinkArgument = "%s"
+	// variableLinkRemainder is replaced by every remaining argument of a
+	// variable link, joined with slashes.
+	variableLinkRemainder = "{*}"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &expandVariableLinkFunction{}
+
+// NewExpandVariableLinkFunction is a helper function to simplify the provider implementation.
+func NewExpandVariableLinkFunction() function.Function {
+	return &expandVariableLinkFunction{}
+}
+
+// expandVariableLinkFunction is the function implementation.
+type expandVariableLinkFunction struct{}
+
+// Metadata returns the function name.
+func (f *expandVariableLinkFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "expand_variable_link"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *expandVariableLinkFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Expand a variable link template",
+		Description: "Substitutes arguments into the destination URL of a variable link. Each `%s` is replaced by the next argument in order and `{*}` by all remaining arguments joined with slashes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "template",
+				Description: "The destination URL of the variable link.",
+			},
+			function.ListParameter{
+				Name:        "args",
+				ElementType: types.StringType,
+				Description: "The arguments to substitute into the template.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run expands the variable link template.
+func (f *expandVariableLinkFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var template string
+	var args []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &template, &args))
+	if resp.Error != nil {
+		return
+	}
+
+	expanded, err := expandVariableLink(template, args)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, expanded))
+}
+
+// expandVariableLink substitutes args into template. It fails when the
+// template has more placeholders than there are arguments.
+func expandVariableLink(template string, args []string) (string, error) {
+	var b strings.Builder
+	next := 0
+	for len(template) > 0 {
+		switch {
+		case strings.HasPrefix(template, variableLinkArgument):
+			if next >= len(args) {
+				return "", fmt.Errorf("the template expects more than %d argument(s)", len(args))
+			}
+			b.WriteString(args[next])
+			next++
+			template = template[len(variableLinkArgument):]
+		case strings.HasPrefix(template, variableLinkRemainder):
+			b.WriteString(strings.Join(args[next:], "/"))
+			next = len(args)
+			template = template[len(variableLinkRemainder):]
+		default:
+			b.WriteByte(template[0])
+			template = template[1:]
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpandVariableLinkFunction(t *testing.T) {
+	tests := []struct {
+		template string
+		args     []string
+		want     string
+	}{
+		{template: "https://example.com", want: "https://example.com"},
+		{template: "https://jira.example.com/browse/%s", args: []string{"ENG-1"}, want: "https://jira.example.com/browse/ENG-1"},
+		{template: "https://github.com/%s/%s/pulls", args: []string{"org", "repo"}, want: "https://github.com/org/repo/pulls"},
+		{template: "https://docs.example.com/{*}", args: []string{"guides", "setup"}, want: "https://docs.example.com/guides/setup"},
+		{template: "https://example.com/%s/{*}", args: []string{"a"}, want: "https://example.com/a/"},
+	}
+
+	for _, test := range tests {
+		got, err := runFunction(t, NewExpandVariableLinkFunction(), types.StringUnknown(),
+			types.StringValue(test.template), stringList(test.args))
+		if err != nil {
+			t.Fatalf("expand_variable_link(%q, %v): %s", test.template, test.args, err)
+		}
+		if want := types.StringValue(test.want); !got.Equal(want) {
+			t.Errorf("expand_variable_link(%q, %v) = %s, want %s", test.template, test.args, got, want)
+		}
+	}
+
+	if _, err := runFunction(t, NewExpandVariableLinkFunction(), types.StringUnknown(),
+		types.StringValue("https://example.com/%s/%s"), stringList([]string{"a"})); err == nil {
+		t.Error("expand_variable_link with too few arguments: expected an error")
+	}
+}
+
+func stringList(values []string) types.List {
+	elems := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elems = append(elems, types.StringValue(value))
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &goURLFunction{}
+
+// NewGoURLFunction is a helper function to simplify the provider implementation.
+func NewGoURLFunction() function.Function {
+	return &goURLFunction{}
+}
+
+// goURLFunction is the function implementation.
+type goURLFunction struct{}
+
+// Metadata returns the function name.
+func (f *goURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "go_url"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *goURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the URL of a go link",
+		Description: "Returns `https://go/<name>`, or the public `https://golinks.io/<name>` URL when `public` is true. Any additional path segments are appended to the URL.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The go link name.",
+			},
+			function.BoolParameter{
+				Name:        "public",
+				Description: "Whether to build the public golinks.io URL, which resolves without the GoLinks browser extension.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "segments",
+			Description: "Path segments appended after the name, for example the arguments of a variable link.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the go link URL.
+func (f *goURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	var public bool
+	var segments []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &public, &segments))
+	if resp.Error != nil {
+		return
+	}
+
+	name = strings.Trim(name, "/")
+	if name == "" {
+		resp.Error = function.NewArgumentFuncError(0, "The go link name must not be empty.")
+		return
+	}
+
+	base := goLinkBaseURL
+	if public {
+		base = publicGoLinkBaseURL
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, base+strings.Join(append([]string{name}, segments...), "/")))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGoURLFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		public   bool
+		segments []string
+		want     string
+	}{
+		{name: "wiki", want: "https://go/wiki"},
+		{name: "wiki", public: true, want: "https://golinks.io/wiki"},
+		{name: "/wiki/", want: "https://go/wiki"},
+		{name: "jira", segments: []string{"ENG", "123"}, want: "https://go/jira/ENG/123"},
+	}
+
+	for _, test := range tests {
+		got, err := runFunction(t, NewGoURLFunction(), types.StringUnknown(),
+			types.StringValue(test.name), types.BoolValue(test.public), segmentsTuple(test.segments))
+		if err != nil {
+			t.Fatalf("go_url(%q): %s", test.name, err)
+		}
+		if want := types.StringValue(test.want); !got.Equal(want) {
+			t.Errorf("go_url(%q) = %s, want %s", test.name, got, want)
+		}
+	}
+
+	if _, err := runFunction(t, NewGoURLFunction(), types.StringUnknown(),
+		types.StringValue(""), types.BoolValue(false), segmentsTuple(nil)); err == nil {
+		t.Error("go_url with an empty name: expected an error")
+	}
+}
+
+// segmentsTuple builds the value Terraform passes for variadic string arguments.
+func segmentsTuple(segments []string) types.Tuple {
+	elemTypes := make([]attr.Type, 0, len(segments))
+	elems := make([]attr.Value, 0, len(segments))
+	for _, segment := range segments {
+		elemTypes = append(elemTypes, types.StringType)
+		elems = append(elems, types.StringValue(segment))
+	}
+	return types.TupleValueMust(elemTypes, elems)
+}
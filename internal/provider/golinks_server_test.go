@@ -0,0 +1,383 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
+)
+
+const (
+	testServerToken = "test-token"
+	testServerCid   = 1
+)
+
+// testServer is an in-memory stand-in for the GoLinks API, so that acceptance
+// tests can exercise the provider without a real GoLinks account.
+type testServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	nextGid int64
+	links   map[int64]*client.GolinkResponse
+	aliases map[string]int64
+	users   []client.UserResponse
+	history map[int64][]client.LinkRevisionResponse
+	pins    map[int64]int64
+
+	// hits holds the redirect hits of each link per day, formatted as
+	// YYYY-MM-DD, served by the analytics endpoint.
+	hits map[int64]map[string]int64
+
+	// signIns counts the requests validating the token.
+	signIns int
+
+	// unavailable is the number of upcoming requests answered with 503.
+	unavailable int
+
+	// createDelay delays every POST request, unless the client gives up
+	// first. It must be set before the server receives requests.
+	createDelay time.Duration
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	s := &testServer{
+		nextGid: 1000,
+		links:   map[int64]*client.GolinkResponse{},
+		aliases: map[string]int64{},
+		history: map[int64][]client.LinkRevisionResponse{},
+		pins:    map[int64]int64{},
+		hits:    map[int64]map[string]int64{},
+		users: []client.UserResponse{
+			{Uid: 1, FirstName: "Test", LastName: "User", Username: "test", Email: "test@example.com"},
+			{Uid: 2, FirstName: "Other", LastName: "User", Username: "other", Email: "other@example.com"},
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// providerConfig returns a provider block pointing at the stand-in server.
+func (s *testServer) providerConfig() string {
+	return fmt.Sprintf(`
+provider "golinks" {
+  host  = %q
+  token = %q
+}
+`, s.URL, testServerToken)
+}
+
+// client returns an API client for the stand-in server.
+func (s *testServer) client(t *testing.T) *client.Client {
+	t.Helper()
+
+	host, token := s.URL, testServerToken
+	c, err := client.NewClient(context.Background(), &host, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// addLink seeds the server with a link created outside of Terraform.
+func (s *testServer) addLink(link client.GolinkResponse) *client.GolinkResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextGid++
+	link.Gid = s.nextGid
+	link.Cid = testServerCid
+	if link.User.Uid == 0 {
+		link.User = s.users[0]
+	}
+	s.links[link.Gid] = &link
+	return &link
+}
+
+// linkByName returns the link named name, or nil when there is none.
+func (s *testServer) linkByName(name string) *client.GolinkResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, link := range s.links {
+		if link.Name == name {
+			return link
+		}
+	}
+	return nil
+}
+
+func (s *testServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+testServerToken {
+		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method == http.MethodPost && s.createDelay > 0 {
+		select {
+		case <-time.After(s.createDelay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unavailable > 0 {
+		s.unavailable--
+		http.Error(w, `{"error":"service unavailable"}`, http.StatusServiceUnavailable)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/":
+		s.signIns++
+		s.writeJSON(w, map[string]string{})
+	case r.URL.Path == "/golinks":
+		s.handleGolinks(w, r)
+	case r.URL.Path == "/golinks/pin" && r.Method == http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		gid, _ := strconv.ParseInt(r.PostForm.Get("gid"), 10, 64)
+		link, ok := s.links[gid]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		link.Pinned = 1
+		s.pins[gid], _ = strconv.ParseInt(r.PostForm.Get("expires_at"), 10, 64)
+		s.writeJSON(w, link)
+	case r.URL.Path == "/golinks/history":
+		gid, _ := strconv.ParseInt(r.URL.Query().Get("gid"), 10, 64)
+		s.writeJSON(w, client.LinkHistoryResponse{Gid: gid, Results: s.history[gid]})
+	case strings.HasPrefix(r.URL.Path, "/golinks/"):
+		gid, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/golinks/"), 10, 64)
+		link, ok := s.links[gid]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		s.writeJSON(w, link)
+	case r.URL.Path == "/analytics":
+		s.handleAnalytics(w, r)
+	case r.URL.Path == "/users":
+		email := r.URL.Query().Get("email")
+		resp := client.UsersResponse{}
+		for _, user := range s.users {
+			if strings.EqualFold(user.Email, email) {
+				resp.Results = append(resp.Results, user)
+			}
+		}
+		s.writeJSON(w, resp)
+	case strings.HasPrefix(r.URL.Path, "/users/"):
+		uid, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/users/"), 10, 64)
+		for _, user := range s.users {
+			if user.Uid == uid {
+				s.writeJSON(w, user)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *testServer) handleGolinks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	switch r.Method {
+	case http.MethodGet:
+		if name := query.Get("name"); name != "" {
+			for _, link := range s.links {
+				if link.Name == name {
+					s.writeJSON(w, link)
+					return
+				}
+			}
+			http.NotFound(w, r)
+			return
+		}
+		if alias := query.Get("alias"); alias != "" {
+			link, ok := s.links[s.aliases[alias]]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			s.writeJSON(w, link)
+			return
+		}
+		s.writeJSON(w, s.list(query))
+	case http.MethodPost, http.MethodPut:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		link := &client.GolinkResponse{Cid: testServerCid, User: s.users[0]}
+		if r.Method == http.MethodPut {
+			gid, _ := strconv.ParseInt(r.PostForm.Get("gid"), 10, 64)
+			existing, ok := s.links[gid]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			link = existing
+		} else {
+			s.nextGid++
+			link.Gid = s.nextGid
+			s.links[link.Gid] = link
+		}
+		s.applyForm(link, r.PostForm)
+		s.writeJSON(w, link)
+	case http.MethodDelete:
+		gid, _ := strconv.ParseInt(query.Get("gid"), 10, 64)
+		delete(s.links, gid)
+		for alias, target := range s.aliases {
+			if target == gid {
+				delete(s.aliases, alias)
+			}
+		}
+		s.writeJSON(w, map[string]string{})
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *testServer) handleAnalytics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	gid, _ := strconv.ParseInt(query.Get("gid"), 10, 64)
+	if _, ok := s.links[gid]; !ok {
+		http.NotFound(w, r)
+		return
+	}
+	start, err := time.Parse(analyticsDateLayout, query.Get("start_date"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	end, err := time.Parse(analyticsDateLayout, query.Get("end_date"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := client.AnalyticsResponse{
+		Gid:       gid,
+		StartDate: query.Get("start_date"),
+		EndDate:   query.Get("end_date"),
+		Series:    []client.DailyHitsResponse{},
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(analyticsDateLayout)
+		resp.Series = append(resp.Series, client.DailyHitsResponse{Date: date, Hits: s.hits[gid][date]})
+	}
+	s.writeJSON(w, resp)
+}
+
+func (s *testServer) list(query url.Values) client.GolinksResponse {
+	gids := make([]int64, 0, len(s.links))
+	for gid := range s.links {
+		gids = append(gids, gid)
+	}
+	for i := 1; i < len(gids); i++ {
+		for j := i; j > 0 && gids[j] < gids[j-1]; j-- {
+			gids[j], gids[j-1] = gids[j-1], gids[j]
+		}
+	}
+
+	limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+	if limit <= 0 {
+		limit = 50
+	}
+	offset, _ := strconv.ParseInt(query.Get("offset"), 10, 64)
+
+	resp := client.GolinksResponse{Results: []client.GolinkResponse{}}
+	for i := offset; i < int64(len(gids)) && i < offset+limit; i++ {
+		resp.Results = append(resp.Results, *s.links[gids[i]])
+	}
+	resp.Metadata = client.MetadataResponse{
+		Limit:        limit,
+		Offset:       offset,
+		Count:        int64(len(resp.Results)),
+		TotalResults: int64(len(gids)),
+	}
+	return resp
+}
+
+func (s *testServer) applyForm(link *client.GolinkResponse, form url.Values) {
+	if link.URL != "" && link.URL != form.Get("url") {
+		revision := client.LinkRevisionResponse{URL: link.URL, Description: link.Description, User: link.User, UpdatedAt: link.UpdatedAt}
+		s.history[link.Gid] = append([]client.LinkRevisionResponse{revision}, s.history[link.Gid]...)
+	}
+
+	link.Name = form.Get("name")
+	link.URL = form.Get("url")
+	link.Description = form.Get("description")
+	link.Unlisted = formInt(form, "unlisted")
+	link.Public = formInt(form, "public")
+	link.Private = formInt(form, "private")
+	// Like the real API, the format and hyphens flags are ignored.
+	if link.Private == 1 {
+		link.Unlisted = 1
+	}
+
+	link.Geolinks = nil
+	for i := 0; form.Has(fmt.Sprintf("geolinks[%d][location]", i)); i++ {
+		link.Geolinks = append(link.Geolinks, client.Geolink{
+			Location: form.Get(fmt.Sprintf("geolinks[%d][location]", i)),
+			URL:      form.Get(fmt.Sprintf("geolinks[%d][url]", i)),
+		})
+	}
+
+	link.Tags = nil
+	for i, tag := range form["tags[]"] {
+		link.Tags = append(link.Tags, client.TagResponse{Tid: int64(i + 1), Name: tag})
+	}
+
+	for alias, target := range s.aliases {
+		if target == link.Gid {
+			delete(s.aliases, alias)
+		}
+	}
+	link.Aliases = form["aliases"]
+	for _, alias := range link.Aliases {
+		s.aliases[alias] = link.Gid
+	}
+
+	if uid, err := strconv.ParseInt(form.Get("uid"), 10, 64); err == nil {
+		for _, user := range s.users {
+			if user.Uid == uid {
+				link.User = user
+			}
+		}
+	}
+}
+
+func formInt(form url.Values, key string) int32 {
+	i, _ := strconv.Atoi(form.Get(key))
+	return int32(i)
+}
+
+func (s *testServer) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
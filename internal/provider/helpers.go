@@ -4,12 +4,19 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"terraform-provider-golinks/internal/client"
+	"terraform-provider-golinks/internal/ownership"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -24,6 +31,159 @@ func BoolToInt(b bool) int32 {
 	return 0
 }
 
+const (
+	// goLinkBaseURL is the base URL of go links resolved through the GoLinks
+	// browser extension.
+	goLinkBaseURL = "https://go/"
+	// publicGoLinkBaseURL is the base URL of go links that can be resolved
+	// without the browser extension.
+	publicGoLinkBaseURL = "https://golinks.io/"
+)
+
+// GoLinkNameFromURL extracts the link name from a go link such as "go/wiki",
+// "http://go/wiki/page" or "https://go/wiki". It reports false when s is not a
+// go link.
+func GoLinkNameFromURL(s string) (string, bool) {
+	name, _, ok := ParseGoLinkURL(s)
+	return name, ok
+}
+
+// ParseGoLinkURL splits a go link such as "go/wiki/page" or
+// "https://golinks.io/wiki/page" into its link name and the path segments
+// that follow it. It reports false when s is not a go link.
+func ParseGoLinkURL(s string) (string, []string, bool) {
+	s = strings.TrimPrefix(s, "https://")
+	s = strings.TrimPrefix(s, "http://")
+
+	rest, ok := strings.CutPrefix(s, "go/")
+	if !ok {
+		rest, ok = strings.CutPrefix(s, "golinks.io/")
+	}
+	if !ok {
+		return "", nil, false
+	}
+
+	rest, _, _ = strings.Cut(rest, "?")
+	rest, _, _ = strings.Cut(rest, "#")
+
+	name, path, _ := strings.Cut(rest, "/")
+	if name == "" {
+		return "", nil, false
+	}
+
+	segments := []string{}
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return name, segments, true
+}
+
+// NormalizeLinkName applies the rules GoLinks uses when the format flag is set
+// on a link: punctuation is removed and whitespace is replaced with hyphens
+// when hyphens is true, or removed otherwise.
+func NormalizeLinkName(name string, hyphens bool) string {
+	var b strings.Builder
+	pendingSpace := false
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = true
+			continue
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+		default:
+			continue
+		}
+		if pendingSpace && hyphens && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		pendingSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// linkNameForAPI returns the name sent to the GoLinks API for model, which is
+// the configured name normalized when format is enabled, qualified with the
+// provider name prefix.
+func linkNameForAPI(model linkResourceModel, settings providerSettings) string {
+	if !model.Format.ValueBool() {
+		return settings.qualifiedName(model.Name.ValueString())
+	}
+	return settings.qualifiedName(NormalizeLinkName(model.Name.ValueString(), model.Hyphens.ValueBool()))
+}
+
+// NameFromResponse strips the provider name prefix from the name returned by
+// the API and keeps the configured name when the API returned its normalized
+// form, so links with format enabled refresh without a diff.
+func NameFromResponse(name types.String, format, hyphens types.Bool, apiName string, settings providerSettings) types.String {
+	apiName = settings.unqualifiedName(apiName)
+	if name.IsNull() || name.IsUnknown() || !format.ValueBool() {
+		return types.StringValue(apiName)
+	}
+	if NormalizeLinkName(name.ValueString(), hyphens.ValueBool()) == apiName {
+		return name
+	}
+	return types.StringValue(apiName)
+}
+
+// localFlag returns the prior value of a flag the API does not persist,
+// falling back to the API value when there is none.
+func localFlag(prior types.Bool, apiValue int32) types.Bool {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.BoolValue(IntToBool(apiValue))
+	}
+	return prior
+}
+
+// GetLinkByGidOrName retrieves the link identified by gid, or by name when gid
+// is null.
+func GetLinkByGidOrName(ctx context.Context, apiClient *client.Client, gid types.Int64, name types.String) (*client.GolinkResponse, error) {
+	if !gid.IsNull() {
+		return apiClient.GetLink(ctx, strconv.FormatInt(gid.ValueInt64(), 10))
+	}
+	return apiClient.GetGolinksByName(ctx, name.ValueString())
+}
+
+// lookupActionLink retrieves the link an action targets, identified by gid or
+// by its name without the provider name prefix. It adds an error to diags and
+// returns nil when the link cannot be found, lies outside the provider
+// namespace, or carries the ownership marker of another workspace and
+// overrideOwnership is false, so that actions never reach links of another
+// team.
+func lookupActionLink(ctx context.Context, apiClient *client.Client, settings providerSettings, gid types.Int64, name types.String, overrideOwnership bool, purpose string, diags *diag.Diagnostics) *client.GolinkResponse {
+	if !name.IsNull() {
+		name = types.StringValue(settings.qualifiedName(name.ValueString()))
+	}
+
+	golink, err := GetLinkByGidOrName(ctx, apiClient, gid, name)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read GoLink",
+			"Could not find the GoLink to "+purpose+": "+err.Error(),
+		)
+		return nil
+	}
+
+	if !settings.ownsName(golink.Name) {
+		diags.AddError(
+			"Link Outside Name Prefixes",
+			fmt.Sprintf("The link %q is outside the namespace of this provider, which only manages links starting with its name_prefix and allowed_name_prefixes.", golink.Name),
+		)
+		return nil
+	}
+
+	if err := settings.Ownership.checkOwner(golink, overrideOwnership); err != nil {
+		diags.AddError(
+			"Link Owned By Another Workspace",
+			"Could not "+purpose+" link: "+err.Error(),
+		)
+		return nil
+	}
+	return golink
+}
+
 func UserToObject(user client.UserResponse) types.Object {
 	obj, _ := types.ObjectValue(UserAttrTypes, map[string]attr.Value{
 		"uid":            types.Int64Value(user.Uid),
@@ -36,29 +196,113 @@ func UserToObject(user client.UserResponse) types.Object {
 	return obj
 }
 
-func MapLinkResponseToModel(resp *client.GolinkResponse, model *linkResourceModel, setLastUpdated bool) {
+func RedirectHitsToObject(hits client.RedirectHitsResponse) types.Object {
+	obj, _ := types.ObjectValue(RedirectHitsAttrTypes, map[string]attr.Value{
+		"daily":   types.Int64Value(hits.Daily),
+		"weekly":  types.Int64Value(hits.Weekly),
+		"monthly": types.Int64Value(hits.Monthly),
+		"alltime": types.Int64Value(hits.Alltime),
+	})
+	return obj
+}
+
+// OwnerFromUser reconciles the configured owner with the user the API reports
+// as owning the link. A matching owner is kept as written so that an email and
+// a uid are both stable; a mismatch is reported in the same form so that
+// ownership changes made outside of Terraform show up as drift.
+func OwnerFromUser(owner types.String, user client.UserResponse) types.String {
+	if owner.IsNull() || owner.IsUnknown() {
+		return owner
+	}
+
+	if uid, err := strconv.ParseInt(owner.ValueString(), 10, 64); err == nil {
+		if uid == user.Uid {
+			return owner
+		}
+		return types.StringValue(strconv.FormatInt(user.Uid, 10))
+	}
+
+	if strings.EqualFold(owner.ValueString(), user.Email) {
+		return owner
+	}
+	return types.StringValue(user.Email)
+}
+
+// appendIgnoredTags appends the tags of current that match ignore to tags, so
+// that writing tags does not remove the ones managed outside of Terraform.
+func appendIgnoredTags(tags []string, current []client.TagResponse, ignore ignoreTags) []string {
+	for _, tag := range current {
+		if ignore.matches(tag.Name) && !slices.Contains(tags, tag.Name) {
+			tags = append(tags, tag.Name)
+		}
+	}
+	return tags
+}
+
+// MapLinkResponseToModel copies the API representation of a link into model.
+// The provider name prefix is stripped from the name and aliases, and
+// ownership markers and ignored tags are left out as they are managed outside
+// of configuration.
+func MapLinkResponseToModel(resp *client.GolinkResponse, model *linkResourceModel, setLastUpdated bool, settings providerSettings) {
 	model.ID = types.StringValue(strconv.FormatInt(resp.Gid, 10))
 	model.Gid = types.Int64Value(resp.Gid)
 	model.Cid = types.Int64Value(resp.Cid)
 	model.URL = types.StringValue(resp.URL)
-	model.Name = types.StringValue(resp.Name)
-	model.Description = types.StringValue(resp.Description)
+	model.Format = localFlag(model.Format, resp.Format)
+	model.Hyphens = localFlag(model.Hyphens, resp.Hyphens)
+	model.Name = NameFromResponse(model.Name, model.Format, model.Hyphens, resp.Name, settings)
+	model.NormalizedName = types.StringValue(resp.Name)
+	model.Description = types.StringValue(ownership.StripFooter(resp.Description))
+	model.DescriptionAll = model.Description
 	model.Unlisted = types.BoolValue(IntToBool(resp.Unlisted))
+	model.Private = types.BoolValue(IntToBool(resp.Private))
+	model.Public = types.BoolValue(IntToBool(resp.Public))
 	model.VariableLink = types.BoolValue(IntToBool(resp.VariableLink))
 	model.Pinned = types.BoolValue(IntToBool(resp.Pinned))
-	model.Format = types.BoolValue(IntToBool(resp.Format))
-	model.Hyphens = types.BoolValue(IntToBool(resp.Hyphens))
 	model.CreatedAt = types.Int64Value(resp.CreatedAt)
 	model.UpdatedAt = types.Int64Value(resp.UpdatedAt)
 	model.User = UserToObject(resp.User)
+	model.Owner = OwnerFromUser(model.Owner, resp.User)
+	model.RedirectHits = RedirectHitsToObject(resp.RedirectHits)
 
 	if setLastUpdated {
 		model.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	}
 
-	var tags []string
+	tags := make([]attr.Value, 0, len(resp.Tags))
 	for _, tag := range resp.Tags {
-		tags = append(tags, tag.Name)
+		if !settings.IgnoreTags.matches(tag.Name) && !ownership.IsMarkerTag(tag.Name) {
+			tags = append(tags, types.StringValue(tag.Name))
+		}
+	}
+	if len(tags) == 0 {
+		model.Tags = types.SetNull(types.StringType)
+	} else {
+		model.Tags, _ = types.SetValue(types.StringType, tags)
+	}
+	model.TagsAll = model.Tags
+
+	aliases := make([]attr.Value, 0, len(resp.Aliases))
+	for _, alias := range resp.Aliases {
+		aliases = append(aliases, types.StringValue(settings.unqualifiedName(alias)))
+	}
+	if len(aliases) == 0 {
+		model.Aliases = types.SetNull(types.StringType)
+	} else {
+		model.Aliases, _ = types.SetValue(types.StringType, aliases)
+	}
+
+	geolinkType := types.ObjectType{AttrTypes: GeolinkAttrTypes}
+	geolinks := make([]attr.Value, 0, len(resp.Geolinks))
+	for _, geolink := range resp.Geolinks {
+		geolinks = append(geolinks, types.ObjectValueMust(GeolinkAttrTypes, map[string]attr.Value{
+			"location": types.StringValue(geolink.Location),
+			"url":      types.StringValue(geolink.URL),
+		}))
+	}
+	if len(geolinks) == 0 {
+		model.Geolinks = types.ListNull(geolinkType)
+	} else {
+		model.Geolinks, _ = types.ListValue(geolinkType, geolinks)
 	}
-	model.Tags = tags
 }
@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// analyticsDateLayout is the date format accepted and returned by the
+// analytics endpoint.
+const analyticsDateLayout = "2006-01-02"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &linkAnalyticsDataSource{}
+	_ datasource.DataSourceWithConfigure = &linkAnalyticsDataSource{}
+)
+
+// LinkAnalyticsDataSource is a helper function to simplify the provider implementation.
+func LinkAnalyticsDataSource() datasource.DataSource {
+	return &linkAnalyticsDataSource{}
+}
+
+// linkAnalyticsDataSource is the data source implementation.
+type linkAnalyticsDataSource struct {
+	client *client.Client
+}
+
+// linkAnalyticsDataSourceModel maps the data source schema data.
+type linkAnalyticsDataSourceModel struct {
+	Gids      []int64              `tfsdk:"gids"`
+	Names     []string             `tfsdk:"names"`
+	StartDate types.String         `tfsdk:"start_date"`
+	EndDate   types.String         `tfsdk:"end_date"`
+	Links     []linkAnalyticsModel `tfsdk:"links"`
+}
+
+// linkAnalyticsModel maps the redirect hit series of a single link.
+type linkAnalyticsModel struct {
+	Gid       types.Int64      `tfsdk:"gid"`
+	Name      types.String     `tfsdk:"name"`
+	TotalHits types.Int64      `tfsdk:"total_hits"`
+	Series    []dailyHitsModel `tfsdk:"series"`
+}
+
+// dailyHitsModel maps the redirect hits of a single day.
+type dailyHitsModel struct {
+	Date types.String `tfsdk:"date"`
+	Hits types.Int64  `tfsdk:"hits"`
+}
+
+// Metadata returns the data source type name.
+func (d *linkAnalyticsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_link_analytics"
+}
+
+// Schema defines the schema for the data source.
+func (d *linkAnalyticsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the per-day redirect hits of one or more GoLinks over a date range.",
+		Attributes: map[string]schema.Attribute{
+			"gids": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "The IDs of the GoLinks to retrieve analytics for.",
+			},
+			"names": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The names of the GoLinks to retrieve analytics for.",
+			},
+			"start_date": schema.StringAttribute{
+				Required:    true,
+				Description: "The first day of the range, formatted as YYYY-MM-DD.",
+			},
+			"end_date": schema.StringAttribute{
+				Required:    true,
+				Description: "The last day of the range, formatted as YYYY-MM-DD.",
+			},
+			"links": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The redirect hit series of each requested GoLink.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"gid": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The GoLink ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the GoLink.",
+						},
+						"total_hits": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The sum of the redirect hits over the range.",
+						},
+						"series": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "The redirect hits of each day in the range.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"date": schema.StringAttribute{
+										Computed:    true,
+										Description: "The day, formatted as YYYY-MM-DD.",
+									},
+									"hits": schema.Int64Attribute{
+										Computed:    true,
+										Description: "The number of redirects on that day.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *linkAnalyticsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state linkAnalyticsDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(state.Gids) == 0 && len(state.Names) == 0 {
+		resp.Diagnostics.AddError(
+			"Missing GoLinks",
+			"The data source requires at least one entry in `gids` or `names` to identify which GoLinks to retrieve analytics for.",
+		)
+		return
+	}
+
+	startDate, err := time.Parse(analyticsDateLayout, state.StartDate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("start_date"),
+			"Invalid Start Date",
+			"The start date must be formatted as YYYY-MM-DD: "+err.Error(),
+		)
+		return
+	}
+
+	endDate, err := time.Parse(analyticsDateLayout, state.EndDate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid End Date",
+			"The end date must be formatted as YYYY-MM-DD: "+err.Error(),
+		)
+		return
+	}
+
+	if endDate.Before(startDate) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid Date Range",
+			"The end date must not be before the start date.",
+		)
+		return
+	}
+
+	// Resolve every requested link to its gid and name.
+	var golinks []*client.GolinkResponse
+	for _, gid := range state.Gids {
+		golink, err := d.client.GetLink(ctx, strconv.FormatInt(gid, 10))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read GoLink",
+				fmt.Sprintf("Could not get link %d: %s", gid, err.Error()),
+			)
+			return
+		}
+		golinks = append(golinks, golink)
+	}
+	for _, name := range state.Names {
+		golink, err := d.client.GetGolinksByName(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read GoLink",
+				fmt.Sprintf("Could not get link %q: %s", name, err.Error()),
+			)
+			return
+		}
+		golinks = append(golinks, golink)
+	}
+
+	state.Links = make([]linkAnalyticsModel, 0, len(golinks))
+	for _, golink := range golinks {
+		analytics, err := d.client.GetLinkAnalytics(ctx, golink.Gid, state.StartDate.ValueString(), state.EndDate.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read GoLink Analytics",
+				fmt.Sprintf("Could not get analytics for link %q: %s", golink.Name, err.Error()),
+			)
+			return
+		}
+
+		linkState := linkAnalyticsModel{
+			Gid:    types.Int64Value(golink.Gid),
+			Name:   types.StringValue(golink.Name),
+			Series: make([]dailyHitsModel, 0, len(analytics.Series)),
+		}
+
+		var total int64
+		for _, day := range analytics.Series {
+			total += day.Hits
+			linkState.Series = append(linkState.Series, dailyHitsModel{
+				Date: types.StringValue(day.Date),
+				Hits: types.Int64Value(day.Hits),
+			})
+		}
+		linkState.TotalHits = types.Int64Value(total)
+
+		state.Links = append(state.Links, linkState)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *linkAnalyticsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *golinks.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestLinkAnalyticsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(t) + `
+resource "golinks_link" "test" {
+  name        = "testlink-analytics"
+  url         = "https://golinks.io"
+  description = "Link used for analytics"
+}
+
+data "golinks_link_analytics" "test" {
+  names      = [golinks_link.test.name]
+  start_date = "2025-01-01"
+  end_date   = "2025-01-07"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.#", "1"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.0.name", "testlink-analytics"),
+					resource.TestCheckResourceAttrSet("data.golinks_link_analytics.test", "links.0.gid"),
+					resource.TestCheckResourceAttrSet("data.golinks_link_analytics.test", "links.0.total_hits"),
+				),
+			},
+		},
+	})
+}
+
+func TestLinkAnalyticsDataSourceSeries(t *testing.T) {
+	server := newTestServer(t)
+	wiki := server.addLink(client.GolinkResponse{Name: "wiki", URL: "https://wiki.example.com"})
+	docs := server.addLink(client.GolinkResponse{Name: "docs", URL: "https://docs.example.com"})
+	server.hits[wiki.Gid] = map[string]int64{"2025-01-02": 3, "2025-01-03": 4, "2025-01-09": 100}
+	server.hits[docs.Gid] = map[string]int64{"2025-01-01": 1}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + fmt.Sprintf(`
+data "golinks_link_analytics" "test" {
+  gids       = [%d]
+  names      = ["wiki"]
+  start_date = "2025-01-01"
+  end_date   = "2025-01-03"
+}
+`, docs.Gid),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.#", "2"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.0.gid", strconv.FormatInt(docs.Gid, 10)),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.0.name", "docs"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.0.total_hits", "1"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.gid", strconv.FormatInt(wiki.Gid, 10)),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.name", "wiki"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.total_hits", "7"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.series.#", "3"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.series.0.date", "2025-01-01"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.series.0.hits", "0"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.series.1.date", "2025-01-02"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.series.1.hits", "3"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.series.2.date", "2025-01-03"),
+					resource.TestCheckResourceAttr("data.golinks_link_analytics.test", "links.1.series.2.hits", "4"),
+				),
+			},
+			{
+				Config: server.providerConfig() + `
+data "golinks_link_analytics" "test" {
+  names      = ["wiki"]
+  start_date = "2025-01-03"
+  end_date   = "2025-01-01"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Date Range`),
+			},
+		},
+	})
+}
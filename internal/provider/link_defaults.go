@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// descriptionSuffix renders the description suffix for the link named name.
+func (d linkDefaults) descriptionSuffix(name string) string {
+	return strings.ReplaceAll(d.DescriptionSuffix, "{name}", name)
+}
+
+// mergeVisibility sets unlisted and public in plan to the provider defaults
+// when config leaves them unset. It reports whether plan changed.
+func (d linkDefaults) mergeVisibility(config linkResourceModel, plan *linkResourceModel) bool {
+	updated := false
+	if config.Unlisted.IsNull() && !d.Unlisted.IsNull() && !plan.Unlisted.Equal(d.Unlisted) {
+		plan.Unlisted = d.Unlisted
+		updated = true
+	}
+	if config.Public.IsNull() && !d.Public.IsNull() && !plan.Public.Equal(d.Public) {
+		plan.Public = d.Public
+		updated = true
+	}
+	return updated
+}
+
+// mergeTagsAndDescription sets tags_all and description_all in plan to the
+// values sent to the API: the configured tags and description combined with
+// the provider defaults. It reports whether plan changed.
+func (d linkDefaults) mergeTagsAndDescription(ctx context.Context, plan *linkResourceModel) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tagsAll := types.SetUnknown(types.StringType)
+	if !plan.Tags.IsUnknown() {
+		var tags []string
+		if !plan.Tags.IsNull() {
+			diags.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+			if diags.HasError() {
+				return false, diags
+			}
+		}
+
+		seen := map[string]bool{}
+		elems := []attr.Value{}
+		for _, tag := range append(tags, d.Tags...) {
+			if !seen[tag] {
+				seen[tag] = true
+				elems = append(elems, types.StringValue(tag))
+			}
+		}
+
+		tagsAll = types.SetNull(types.StringType)
+		if len(elems) > 0 {
+			var setDiags diag.Diagnostics
+			tagsAll, setDiags = types.SetValue(types.StringType, elems)
+			diags.Append(setDiags...)
+		}
+	}
+
+	descriptionAll := types.StringUnknown()
+	nameKnown := !plan.NormalizedName.IsUnknown() || !strings.Contains(d.DescriptionSuffix, "{name}")
+	if !plan.Description.IsUnknown() && nameKnown {
+		descriptionAll = types.StringValue(plan.Description.ValueString() + d.descriptionSuffix(plan.NormalizedName.ValueString()))
+	}
+
+	updated := !plan.TagsAll.Equal(tagsAll) || !plan.DescriptionAll.Equal(descriptionAll)
+	plan.TagsAll = tagsAll
+	plan.DescriptionAll = descriptionAll
+	return updated, diags
+}
+
+// removeFrom derives tags and description of model from the tags_all and
+// description_all values returned by the API, leaving out what the provider
+// defaults added. A default tag is kept when priorTags configured it too.
+func (d linkDefaults) removeFrom(ctx context.Context, model *linkResourceModel, priorTags types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.Description = model.DescriptionAll
+	if suffix := d.descriptionSuffix(model.NormalizedName.ValueString()); suffix != "" {
+		model.Description = types.StringValue(strings.TrimSuffix(model.DescriptionAll.ValueString(), suffix))
+	}
+
+	if len(d.Tags) == 0 {
+		model.Tags = model.TagsAll
+		return diags
+	}
+
+	configured := map[string]bool{}
+	if !priorTags.IsNull() && !priorTags.IsUnknown() {
+		var tags []string
+		diags.Append(priorTags.ElementsAs(ctx, &tags, false)...)
+		for _, tag := range tags {
+			configured[tag] = true
+		}
+	}
+	defaults := map[string]bool{}
+	for _, tag := range d.Tags {
+		defaults[tag] = true
+	}
+
+	var all []string
+	if !model.TagsAll.IsNull() {
+		diags.Append(model.TagsAll.ElementsAs(ctx, &all, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	elems := []attr.Value{}
+	for _, tag := range all {
+		if !defaults[tag] || configured[tag] {
+			elems = append(elems, types.StringValue(tag))
+		}
+	}
+
+	model.Tags = types.SetNull(types.StringType)
+	if len(elems) > 0 {
+		var setDiags diag.Diagnostics
+		model.Tags, setDiags = types.SetValue(types.StringType, elems)
+		diags.Append(setDiags...)
+	}
+	return diags
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &linkListResource{}
+	_ list.ListResourceWithConfigure = &linkListResource{}
+)
+
+// NewLinkListResource is a helper function to simplify the provider implementation.
+func NewLinkListResource() list.ListResource {
+	return &linkListResource{}
+}
+
+// linkListResource is the list resource implementation.
+type linkListResource struct {
+	client   *client.Client
+	settings providerSettings
+}
+
+// linkListModel maps the list resource schema data.
+type linkListModel struct {
+	Tag        types.String `tfsdk:"tag"`
+	Owner      types.String `tfsdk:"owner"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+// Metadata returns the list resource type name, matching the managed resource.
+func (r *linkListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_link"
+}
+
+// ListResourceConfigSchema defines the schema for list blocks.
+func (r *linkListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing GoLinks. When the provider sets `name_prefix` or `allowed_name_prefixes`, only the links inside that namespace are listed.",
+		Attributes: map[string]schema.Attribute{
+			"tag": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list links with this tag.",
+			},
+			"owner": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list links owned by this user, given as an email address or uid.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list links whose name, without the provider `name_prefix`, starts with this prefix.",
+			},
+		},
+	}
+}
+
+// List streams the links that match the configured filters.
+func (r *linkListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config linkListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	golinks, err := r.client.GetAllGolinks(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to List GoLinks",
+			err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, golink := range golinks {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			if !r.settings.ownsName(golink.Name) || !config.matches(golink, r.settings) {
+				continue
+			}
+			count++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = golink.Name
+
+			result.Diagnostics.Append(result.Identity.Set(ctx, linkIdentityModel{
+				Cid:  types.Int64Value(golink.Cid),
+				Gid:  types.Int64Value(golink.Gid),
+				Name: types.StringNull(),
+			})...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				model := newLinkResourceModel()
+				MapLinkResponseToModel(&golink, &model, false, r.settings)
+				result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// matches reports whether golink satisfies every configured filter. The name
+// filter applies to the name without the provider name prefix, like the name
+// of golinks_link.
+func (m linkListModel) matches(golink client.GolinkResponse, settings providerSettings) bool {
+	name := settings.unqualifiedName(golink.Name)
+	if !m.NamePrefix.IsNull() && !strings.HasPrefix(name, m.NamePrefix.ValueString()) {
+		return false
+	}
+
+	if !m.Owner.IsNull() {
+		owner := m.Owner.ValueString()
+		if uid, err := strconv.ParseInt(owner, 10, 64); err == nil {
+			if golink.User.Uid != uid {
+				return false
+			}
+		} else if !strings.EqualFold(golink.User.Email, owner) {
+			return false
+		}
+	}
+
+	if !m.Tag.IsNull() {
+		found := false
+		for _, tag := range golink.Tags {
+			if tag.Name == m.Tag.ValueString() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *linkListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.settings = data.Settings
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestLinkListResource(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+	wiki := server.addLink(client.GolinkResponse{Name: "eng-wiki", URL: "https://wiki", Tags: []client.TagResponse{{Tid: 1, Name: "eng"}}})
+	server.addLink(client.GolinkResponse{Name: "eng-oncall", URL: "https://oncall"})
+	server.addLink(client.GolinkResponse{Name: "sales-deck", URL: "https://deck", Tags: []client.TagResponse{{Tid: 1, Name: "eng"}}})
+
+	host, token := server.URL, testServerToken
+	c, err := client.NewClient(ctx, &host, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &linkListResource{client: c}
+
+	var listSchema list.ListResourceSchemaResponse
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &listSchema)
+	var resourceSchema resource.SchemaResponse
+	(&linkResource{}).Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	var identitySchema resource.IdentitySchemaResponse
+	(&linkResource{}).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+
+	config := tfsdk.Config{
+		Schema: listSchema.Schema,
+		Raw: tftypes.NewValue(listSchema.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"tag":         tftypes.NewValue(tftypes.String, "eng"),
+			"owner":       tftypes.NewValue(tftypes.String, "test@example.com"),
+			"name_prefix": tftypes.NewValue(tftypes.String, "eng-"),
+		}),
+	}
+
+	var stream list.ListResultsStream
+	r.List(ctx, list.ListRequest{
+		Config:                 config,
+		IncludeResource:        true,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}, &stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].DisplayName != "eng-wiki" {
+		t.Errorf("expected display name eng-wiki, got %q", results[0].DisplayName)
+	}
+
+	var identity linkIdentityModel
+	if diags := results[0].Identity.Get(ctx, &identity); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if identity.Gid.ValueInt64() != wiki.Gid || identity.Cid.ValueInt64() != testServerCid {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+
+	var model linkResourceModel
+	if diags := results[0].Resource.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if model.URL.ValueString() != "https://wiki" {
+		t.Errorf("expected url https://wiki, got %q", model.URL.ValueString())
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"terraform-provider-golinks/internal/client"
+	"terraform-provider-golinks/internal/ownership"
+)
+
+const (
+	// ownershipMarkerTag stamps the workspace into a reserved tag.
+	ownershipMarkerTag = "tag"
+	// ownershipMarkerDescription stamps the workspace into a description
+	// footer.
+	ownershipMarkerDescription = "description"
+)
+
+// linkOwnership holds the values of the provider ownership_marker block.
+type linkOwnership struct {
+	// Workspace identifies the Terraform workspace managing the links. The
+	// ownership marker is disabled when empty.
+	Workspace string
+
+	// Marker is ownershipMarkerTag or ownershipMarkerDescription.
+	Marker string
+}
+
+// enabled reports whether links are stamped with an ownership marker.
+func (o linkOwnership) enabled() bool {
+	return o.Workspace != ""
+}
+
+// stamp adds the ownership marker of the workspace to the tags or the
+// description written to the API.
+func (o linkOwnership) stamp(tags []string, description string) ([]string, string) {
+	if !o.enabled() {
+		return tags, description
+	}
+	if o.Marker == ownershipMarkerDescription {
+		return tags, description + ownership.FooterPrefix + o.Workspace
+	}
+	return append(tags, ownership.TagPrefix+o.Workspace), description
+}
+
+// linkWorkspace returns the workspace named by the ownership marker of link,
+// whichever marker style stamped it.
+func linkWorkspace(link *client.GolinkResponse) (string, bool) {
+	for _, tag := range link.Tags {
+		if workspace, ok := strings.CutPrefix(tag.Name, ownership.TagPrefix); ok {
+			return workspace, true
+		}
+	}
+	if workspace, ok := ownership.FooterWorkspace(link.Description); ok {
+		return workspace, true
+	}
+	return "", false
+}
+
+// checkOwner returns an error when link carries the ownership marker of
+// another workspace and override is false.
+func (o linkOwnership) checkOwner(link *client.GolinkResponse, override bool) error {
+	if !o.enabled() || override {
+		return nil
+	}
+	workspace, ok := linkWorkspace(link)
+	if !ok || workspace == o.Workspace {
+		return nil
+	}
+	return fmt.Errorf("link %q is managed by the Terraform workspace %q, not by %q; set `override_ownership = true` to take it over", link.Name, workspace, o.Workspace)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"terraform-provider-golinks/internal/client"
+	"terraform-provider-golinks/internal/ownership"
+)
+
+func TestLinkOwnership(t *testing.T) {
+	tagOwnership := linkOwnership{Workspace: "payments-prod", Marker: ownershipMarkerTag}
+	tags, description := tagOwnership.stamp([]string{"docs"}, "Team wiki")
+	if !slices.Equal(tags, []string{"docs", "managed-by:payments-prod"}) || description != "Team wiki" {
+		t.Errorf("tag stamp = %v, %q", tags, description)
+	}
+
+	descriptionOwnership := linkOwnership{Workspace: "payments-prod", Marker: ownershipMarkerDescription}
+	tags, description = descriptionOwnership.stamp([]string{"docs"}, "Team wiki")
+	if !slices.Equal(tags, []string{"docs"}) || ownership.StripFooter(description) != "Team wiki" {
+		t.Errorf("description stamp = %v, %q", tags, description)
+	}
+
+	tests := []struct {
+		name     string
+		link     client.GolinkResponse
+		override bool
+		wantErr  bool
+	}{
+		{name: "unmarked", link: client.GolinkResponse{Name: "wiki"}},
+		{name: "own tag", link: client.GolinkResponse{Name: "wiki", Tags: []client.TagResponse{{Name: "managed-by:payments-prod"}}}},
+		{name: "foreign tag", link: client.GolinkResponse{Name: "wiki", Tags: []client.TagResponse{{Name: "managed-by:sales"}}}, wantErr: true},
+		{name: "foreign footer", link: client.GolinkResponse{Name: "wiki", Description: "Wiki" + ownership.FooterPrefix + "sales"}, wantErr: true},
+		{name: "foreign tag overridden", link: client.GolinkResponse{Name: "wiki", Tags: []client.TagResponse{{Name: "managed-by:sales"}}}, override: true},
+	}
+	for _, test := range tests {
+		err := tagOwnership.checkOwner(&test.link, test.override)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: checkOwner() error = %v, want error %t", test.name, err, test.wantErr)
+		}
+	}
+
+	if err := (linkOwnership{}).checkOwner(&tests[2].link, false); err != nil {
+		t.Errorf("disabled ownership must not check owners, got: %v", err)
+	}
+}
@@ -8,54 +8,121 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"terraform-provider-golinks/internal/client"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const (
+	deletionPolicyDelete  = "delete"
+	deletionPolicyAbandon = "abandon"
+
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &linkResource{}
-	_ resource.ResourceWithConfigure   = &linkResource{}
-	_ resource.ResourceWithImportState = &linkResource{}
-	_ resource.ResourceWithModifyPlan  = &linkResource{}
+	_ resource.Resource                 = &linkResource{}
+	_ resource.ResourceWithConfigure    = &linkResource{}
+	_ resource.ResourceWithImportState  = &linkResource{}
+	_ resource.ResourceWithModifyPlan   = &linkResource{}
+	_ resource.ResourceWithIdentity     = &linkResource{}
+	_ resource.ResourceWithUpgradeState = &linkResource{}
 )
 
 // linksResource is the resource implementation.
 type linkResource struct {
-	client *client.Client
+	client   *client.Client
+	settings providerSettings
+	lookups  *lookupCache
 }
 
 // golinkResourceModel maps the resource schema data.
 type linkResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Gid          types.Int64  `tfsdk:"gid"`
-	Cid          types.Int64  `tfsdk:"cid"`
-	LastUpdated  types.String `tfsdk:"last_updated"`
-	User         types.Object `tfsdk:"user"`
-	URL          types.String `tfsdk:"url"`
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Tags         []string     `tfsdk:"tags"`
-	Unlisted     types.Bool   `tfsdk:"unlisted"`
-	Private      types.Bool   `tfsdk:"private"`
-	Public       types.Bool   `tfsdk:"public"`
-	VariableLink types.Bool   `tfsdk:"variable_link"`
-	Pinned       types.Bool   `tfsdk:"pinned"`
-	Format       types.Bool   `tfsdk:"format"`
-	Hyphens      types.Bool   `tfsdk:"hyphens"`
-	Aliases      types.List   `tfsdk:"aliases"`
-	Geolinks     types.List   `tfsdk:"geolinks"`
-	CreatedAt    types.Int64  `tfsdk:"created_at"`
-	UpdatedAt    types.Int64  `tfsdk:"updated_at"`
+	ID             types.String `tfsdk:"id"`
+	Gid            types.Int64  `tfsdk:"gid"`
+	Cid            types.Int64  `tfsdk:"cid"`
+	LastUpdated    types.String `tfsdk:"last_updated"`
+	User           types.Object `tfsdk:"user"`
+	URL            types.String `tfsdk:"url"`
+	Name           types.String `tfsdk:"name"`
+	NormalizedName types.String `tfsdk:"normalized_name"`
+	Description    types.String `tfsdk:"description"`
+	DescriptionAll types.String `tfsdk:"description_all"`
+	Tags           types.Set    `tfsdk:"tags"`
+	TagsAll        types.Set    `tfsdk:"tags_all"`
+	Unlisted       types.Bool   `tfsdk:"unlisted"`
+	Private        types.Bool   `tfsdk:"private"`
+	Public         types.Bool   `tfsdk:"public"`
+	VariableLink   types.Bool   `tfsdk:"variable_link"`
+	Pinned         types.Bool   `tfsdk:"pinned"`
+	Format         types.Bool   `tfsdk:"format"`
+	Hyphens        types.Bool   `tfsdk:"hyphens"`
+	Aliases        types.Set    `tfsdk:"aliases"`
+	Geolinks       types.List   `tfsdk:"geolinks"`
+	CreatedAt      types.Int64  `tfsdk:"created_at"`
+	UpdatedAt      types.Int64  `tfsdk:"updated_at"`
+	Owner          types.String `tfsdk:"owner"`
+	RedirectHits   types.Object `tfsdk:"redirect_hits"`
+
+	ForceDestroyHeavilyUsed types.Bool   `tfsdk:"force_destroy_heavily_used"`
+	OverrideOwnership       types.Bool   `tfsdk:"override_ownership"`
+	AdoptExisting           types.Bool   `tfsdk:"adopt_existing"`
+	DeletionProtection      types.Bool   `tfsdk:"deletion_protection"`
+	DeletionPolicy          types.String `tfsdk:"deletion_policy"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// linkIdentityModel maps the resource identity schema data.
+type linkIdentityModel struct {
+	Cid  types.Int64  `tfsdk:"cid"`
+	Gid  types.Int64  `tfsdk:"gid"`
+	Name types.String `tfsdk:"name"`
+}
+
+// newLinkResourceModel returns a model with the attributes that the API does
+// not report set to typed nulls or their defaults, ready to be populated by
+// MapLinkResponseToModel.
+func newLinkResourceModel() linkResourceModel {
+	return linkResourceModel{
+		Tags:                    types.SetNull(types.StringType),
+		TagsAll:                 types.SetNull(types.StringType),
+		Aliases:                 types.SetNull(types.StringType),
+		Geolinks:                types.ListNull(types.ObjectType{AttrTypes: GeolinkAttrTypes}),
+		ForceDestroyHeavilyUsed: types.BoolValue(false),
+		OverrideOwnership:       types.BoolValue(false),
+		DeletionProtection:      types.BoolValue(false),
+		DeletionPolicy:          types.StringValue(deletionPolicyDelete),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"read":   types.StringType,
+				"update": types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
 }
 
 // NewLinksResource is a helper function to simplify the provider implementation.
@@ -69,9 +136,18 @@ func (r *linkResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *linkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *linkResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a GoLink.",
+		Version:     1,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -104,17 +180,30 @@ func (r *linkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
-				Description: "The link name.",
+				Description: "The link name, without the provider `name_prefix`.",
+			},
+			"normalized_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The link name as stored by GoLinks, after applying the `format` and `hyphens` rules to `name`.",
 			},
 			"description": schema.StringAttribute{
 				Required:    true,
 				Description: "Brief description of the link.",
 			},
-			"tags": schema.ListAttribute{
+			"description_all": schema.StringAttribute{
+				Computed:    true,
+				Description: "The description stored by GoLinks: `description` followed by the `description_suffix` of the provider `defaults` block.",
+			},
+			"tags": schema.SetAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
 				Description: "Organize your golinks and find the right ones quickly with tags.",
 			},
+			"tags_all": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "All tags of the link, including those added by the `tags` of the provider `defaults` block.",
+			},
 			"unlisted": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -138,7 +227,8 @@ func (r *linkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"format": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "If the value is true, invalid characters (e.g. punctuation) will be removed from the created go link name.",
+				Description: "If the value is true, invalid characters (e.g. punctuation) will be removed from the created go link name. The provider applies this rule itself and reports the result in `normalized_name`.",
+				Default:     booldefault.StaticBool(false),
 			},
 			"hyphens": schema.BoolAttribute{
 				Optional:    true,
@@ -146,10 +236,10 @@ func (r *linkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "If the value is true, spaces will be replaced with hyphens in the go link name. If false, spaces will be removed. Requires format set to true.",
 				Default:     booldefault.StaticBool(false),
 			},
-			"aliases": schema.ListAttribute{
+			"aliases": schema.SetAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
-				Description: "Create multiple names for the same link with aliases.",
+				Description: "Create multiple names for the same link with aliases, without the provider `name_prefix`.",
 			},
 			"geolinks": schema.ListNestedAttribute{
 				Optional:    true,
@@ -177,14 +267,100 @@ func (r *linkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"user": schema.SingleNestedAttribute{
 				Computed:    true,
-				Description: "The user who created the golink.",
+				Description: "The user who owns the golink.",
 				Attributes:  UserResourceSchemaAttributes,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, creating the link takes over an existing link with the same name instead of failing, updating it to the configured values. Defaults to the provider's `adopt_existing_links`.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "If true, the link cannot be destroyed or replaced. Set it to false and apply before removing the link. Defaults to the provider's `deletion_protection`.",
+			},
+			"deletion_policy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(deletionPolicyDelete),
+				Description: "What happens to the link when the resource is destroyed: `delete` removes it from GoLinks, `abandon` only removes it from the Terraform state. Defaults to `delete`.",
+			},
+			"redirect_hits": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The redirect hits statistics for the golink, refreshed on every read.",
+				Attributes:  RedirectHitsResourceSchemaAttributes,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Optional:    true,
+				Description: "Email address or uid of the user who should own the golink. Changing this value transfers ownership of the link.",
+			},
+			"force_destroy_heavily_used": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, the link may be destroyed or replaced even when its monthly redirect hits exceed the provider's `destroy_guard_monthly_hits`. Must be applied before the destroy to take effect.",
+			},
+			"override_ownership": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, the link may be updated, adopted or deleted even when its ownership marker names another Terraform workspace than the provider's `ownership_marker`. Must be applied before a destroy to take effect.",
+			},
+		},
+	}
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *linkResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"cid": identityschema.Int64Attribute{
+				RequiredForImport: true,
+				Description:       "The Company ID.",
+			},
+			"gid": identityschema.Int64Attribute{
+				OptionalForImport: true,
+				Description:       "The GoLink ID. Either gid or name must be set on import.",
+			},
+			"name": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The link name, only used to look up the link on import when gid is not set. It is not stored, as renaming a link must not change its identity.",
+			},
 		},
 	}
 }
 
+// setIdentity records the identity of the link described by model.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, model linkResourceModel) diag.Diagnostics {
+	// Terraform versions before 1.12 do not support identity.
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, linkIdentityModel{
+		Cid:  model.Cid,
+		Gid:  model.Gid,
+		Name: types.StringNull(),
+	})
+}
+
 func (r *linkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Checked last, against the plan as modified below.
+	defer r.checkReadOnly(ctx, req, resp)
+
+	r.checkDeletionProtection(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.guardHeavilyUsedDestroy(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if req.Plan.Raw.IsNull() {
 		return
 	}
@@ -205,6 +381,24 @@ func (r *linkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 
 	planUpdated := false
 
+	if !plan.DeletionPolicy.IsUnknown() && plan.DeletionPolicy.ValueString() != deletionPolicyDelete && plan.DeletionPolicy.ValueString() != deletionPolicyAbandon {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_policy"),
+			"Invalid Deletion Policy",
+			fmt.Sprintf("The deletion policy must be %q or %q, got: %q.", deletionPolicyDelete, deletionPolicyAbandon, plan.DeletionPolicy.ValueString()),
+		)
+		return
+	}
+
+	if config.DeletionProtection.IsNull() && (plan.DeletionProtection.IsUnknown() || plan.DeletionProtection.ValueBool() != r.settings.DeletionProtection) {
+		plan.DeletionProtection = types.BoolValue(r.settings.DeletionProtection)
+		planUpdated = true
+	}
+
+	if r.settings.Defaults.mergeVisibility(config, &plan) {
+		planUpdated = true
+	}
+
 	privateKnown := !plan.Private.IsNull() && !plan.Private.IsUnknown()
 	privateTrue := privateKnown && plan.Private.ValueBool()
 
@@ -225,26 +419,88 @@ func (r *linkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 		}
 	}
 
+	formatKnown := !plan.Format.IsNull() && !plan.Format.IsUnknown()
 	hyphensKnown := !plan.Hyphens.IsNull() && !plan.Hyphens.IsUnknown()
-	hyphensTrue := hyphensKnown && plan.Hyphens.ValueBool()
 
-	if hyphensTrue {
+	if formatKnown && !plan.Format.ValueBool() && hyphensKnown && plan.Hyphens.ValueBool() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("hyphens"),
-			"Hyphens Not Supported",
-			"The GoLinks API currently ignores the `hyphens` flag, so the provider cannot manage it. Remove this attribute from configuration.",
+			"Hyphens Requires Format",
+			"Spaces are only replaced with hyphens when the name is formatted. Set `format` to true or remove `hyphens` from configuration.",
 		)
 		return
 	}
 
-	formatKnown := !plan.Format.IsNull() && !plan.Format.IsUnknown()
-	formatTrue := formatKnown && plan.Format.ValueBool()
-	if formatTrue {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("format"),
-			"Format Not Supported",
-			"The GoLinks API currently ignores the `format` flag, so the provider cannot manage it. Remove this attribute from configuration.",
-		)
+	// The GoLinks API ignores the format and hyphens flags, so the name is
+	// normalized here and the API only ever receives the normalized name.
+	normalizedName := types.StringUnknown()
+	if !plan.Name.IsUnknown() && formatKnown && hyphensKnown {
+		normalizedName = types.StringValue(linkNameForAPI(plan, r.settings))
+		if normalizedName.ValueString() == r.settings.NamePrefix {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Invalid Link Name",
+				fmt.Sprintf("The name %q is empty once formatted. Use a name with at least one letter or digit.", plan.Name.ValueString()),
+			)
+			return
+		}
+		if !r.settings.allowsName(normalizedName.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Name Outside Allowed Prefixes",
+				fmt.Sprintf("The link name %q does not start with any of the provider allowed_name_prefixes: %s.", normalizedName.ValueString(), strings.Join(r.settings.AllowedNamePrefixes, ", ")),
+			)
+			return
+		}
+	}
+
+	if !plan.Aliases.IsNull() && !plan.Aliases.IsUnknown() && len(r.settings.AllowedNamePrefixes) > 0 {
+		var aliases []string
+		resp.Diagnostics.Append(plan.Aliases.ElementsAs(ctx, &aliases, false)...)
+		for _, alias := range aliases {
+			alias = r.settings.qualifiedName(alias)
+			if !r.settings.allowsName(alias) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("aliases"),
+					"Alias Outside Allowed Prefixes",
+					fmt.Sprintf("The alias %q does not start with any of the provider allowed_name_prefixes: %s.", alias, strings.Join(r.settings.AllowedNamePrefixes, ", ")),
+				)
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !plan.NormalizedName.Equal(normalizedName) {
+		plan.NormalizedName = normalizedName
+		planUpdated = true
+	}
+
+	merged, diags := r.settings.Defaults.mergeTagsAndDescription(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if merged {
+		planUpdated = true
+	}
+
+	if r.settings.IgnoreTags.enabled() && !plan.TagsAll.IsNull() && !plan.TagsAll.IsUnknown() {
+		var tags []string
+		resp.Diagnostics.Append(plan.TagsAll.ElementsAs(ctx, &tags, false)...)
+		for _, tag := range tags {
+			if r.settings.IgnoreTags.matches(tag) {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("tags"),
+					"Ignored Tag Configured",
+					fmt.Sprintf("The tag %q matches the provider ignore_tags configuration, so it is never read back and every plan will try to add it again.", tag),
+				)
+			}
+		}
+	}
+
+	r.checkNameCollisions(ctx, req, plan, resp)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -254,6 +510,251 @@ func (r *linkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 	}
 }
 
+// checkNameCollisions raises an error when a new name or alias of the planned
+// link is already used by another link.
+func (r *linkResource) checkNameCollisions(ctx context.Context, req resource.ModifyPlanRequest, plan linkResourceModel, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || r.lookups == nil {
+		return
+	}
+
+	var state linkResourceModel
+	creating := req.State.Raw.IsNull()
+	if !creating {
+		diags := req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Adoption takes over the link using the name on purpose.
+	adopt := r.settings.AdoptExistingLinks
+	if !plan.AdoptExisting.IsNull() && !plan.AdoptExisting.IsUnknown() {
+		adopt = plan.AdoptExisting.ValueBool()
+	}
+	// Compare the names the API sees, which differ from the configured
+	// ones when format is enabled.
+	stateName := state.NormalizedName
+	if stateName.IsNull() {
+		stateName = state.Name
+	}
+	checkName := !plan.NormalizedName.IsUnknown() && (creating && !adopt || !creating && plan.NormalizedName.ValueString() != stateName.ValueString())
+
+	if checkName {
+		r.checkKeyCollision(ctx, plan.NormalizedName.ValueString(), state.Gid.ValueInt64(), path.Root("name"), resp)
+	}
+
+	// The aliases of an adopted link are taken over together with it.
+	ownGid := state.Gid.ValueInt64()
+	if creating && adopt && !plan.NormalizedName.IsUnknown() {
+		gid, err := r.lookups.linkUsing(ctx, r.client, plan.NormalizedName.ValueString())
+		if err == nil {
+			ownGid = gid
+		}
+	}
+
+	if plan.Aliases.IsNull() || plan.Aliases.IsUnknown() {
+		return
+	}
+
+	var aliases []string
+	diags := plan.Aliases.ElementsAs(ctx, &aliases, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing := map[string]bool{}
+	if !state.Aliases.IsNull() && !state.Aliases.IsUnknown() {
+		var stateAliases []string
+		diags = state.Aliases.ElementsAs(ctx, &stateAliases, true)
+		resp.Diagnostics.Append(diags...)
+		for _, alias := range stateAliases {
+			existing[alias] = true
+		}
+	}
+
+	for _, alias := range aliases {
+		if alias == "" || existing[alias] {
+			continue
+		}
+		r.checkKeyCollision(ctx, r.settings.qualifiedName(alias), ownGid, path.Root("aliases").AtSetValue(types.StringValue(alias)), resp)
+	}
+}
+
+// checkKeyCollision reports an error on attrPath when key is used by a link
+// other than ownGid.
+func (r *linkResource) checkKeyCollision(ctx context.Context, key string, ownGid int64, attrPath path.Path, resp *resource.ModifyPlanResponse) {
+	gid, err := r.lookups.linkUsing(ctx, r.client, key)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			attrPath,
+			"Unable to Check Name Availability",
+			fmt.Sprintf("Could not check whether %q is already in use, the apply may fail if it is: %s", key, err.Error()),
+		)
+		return
+	}
+
+	if gid != 0 && gid != ownGid {
+		resp.Diagnostics.AddAttributeError(
+			attrPath,
+			"Name Already In Use",
+			fmt.Sprintf("%q is already used by link %d, which is not managed by this resource. "+
+				"Choose another name, import that link, or set `adopt_existing = true` to take it over.", key, gid),
+		)
+	}
+}
+
+// checkDeletionProtection fails the plan when it destroys or replaces a link
+// whose applied state has deletion protection enabled.
+func (r *linkResource) checkDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() {
+		replacing, diags := planReplacesLink(ctx, req)
+		resp.Diagnostics.Append(diags...)
+		if !replacing || resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var state linkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Link Is Protected From Deletion",
+			fmt.Sprintf("The link %q has deletion_protection enabled, so it cannot be destroyed or replaced. "+
+				"Set `deletion_protection = false` and apply that change first.", state.Name.ValueString()),
+		)
+	}
+}
+
+// checkReadOnly fails the plan when the provider is read-only and the plan
+// creates, updates or destroys the link. Abandoning a link does not change
+// it and is allowed.
+func (r *linkResource) checkReadOnly(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.settings.ReadOnly || resp.Diagnostics.HasError() || resp.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	if resp.Plan.Raw.IsNull() {
+		var state linkResourceModel
+		diags := req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if state.DeletionPolicy.ValueString() == deletionPolicyAbandon {
+			return
+		}
+	}
+
+	var action string
+	switch {
+	case req.State.Raw.IsNull():
+		action = "created"
+	case resp.Plan.Raw.IsNull():
+		action = "destroyed"
+	default:
+		action = "updated"
+	}
+
+	resp.Diagnostics.AddError(
+		"Provider Is Read-Only",
+		fmt.Sprintf("The link cannot be %s as the provider is configured with `read_only = true`. "+
+			"Use a provider configuration without read_only to change links.", action),
+	)
+}
+
+// planReplacesLink reports whether the plan replaces the link. The framework
+// does not pass the RequiresReplace of the attribute plan modifiers to the
+// resource ModifyPlan, so the attributes requiring replacement are compared
+// between state and plan here.
+func planReplacesLink(ctx context.Context, req resource.ModifyPlanRequest) (bool, diag.Diagnostics) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return false, nil
+	}
+
+	var statePrivate, planPrivate types.Bool
+	diags := req.State.GetAttribute(ctx, path.Root("private"), &statePrivate)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("private"), &planPrivate)...)
+	return !planPrivate.Equal(statePrivate), diags
+}
+
+// guardHeavilyUsedDestroy fails the plan when it destroys or replaces a link
+// whose monthly redirect hits exceed the provider's destroy guard threshold.
+func (r *linkResource) guardHeavilyUsedDestroy(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	threshold := r.settings.DestroyGuardMonthlyHits
+	if threshold <= 0 || req.State.Raw.IsNull() {
+		return
+	}
+
+	destroying := req.Plan.Raw.IsNull()
+	if !destroying {
+		replacing, diags := planReplacesLink(ctx, req)
+		resp.Diagnostics.Append(diags...)
+		if !replacing || resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var state linkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An abandoned link keeps serving redirects.
+	if destroying && state.DeletionPolicy.ValueString() == deletionPolicyAbandon {
+		return
+	}
+
+	// A destroy has no configuration, so the override must already be in
+	// state. A replacement may set it in the same plan.
+	force := state.ForceDestroyHeavilyUsed
+	if !destroying {
+		diags = req.Plan.GetAttribute(ctx, path.Root("force_destroy_heavily_used"), &force)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if force.ValueBool() {
+		return
+	}
+
+	linkresponse, err := r.client.GetLink(ctx, state.ID.ValueString())
+	if client.IsNotFound(err) {
+		// A link that is already gone cannot be heavily used.
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving link",
+			"Could not get link usage before destroying it, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if linkresponse.RedirectHits.Monthly > threshold {
+		resp.Diagnostics.AddError(
+			"Heavily Used Link Cannot Be Destroyed",
+			fmt.Sprintf("The link %q was hit %d times in the last month, above the provider's destroy_guard_monthly_hits of %d. "+
+				"Set `force_destroy_heavily_used = true` and apply it before destroying or replacing this link.",
+				linkresponse.Name, linkresponse.RedirectHits.Monthly, threshold),
+		)
+	}
+}
+
 // Create a new resource.
 func (r *linkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -264,11 +765,20 @@ func (r *linkResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	var link client.CreateLinkRequest
 	link.URL = plan.URL.ValueString()
-	link.Name = plan.Name.ValueString()
-	link.Description = plan.Description.ValueString()
+	link.Name = linkNameForAPI(plan, r.settings)
+	link.Description = plan.DescriptionAll.ValueString()
 
 	privateVal := !plan.Private.IsNull() && !plan.Private.IsUnknown() && plan.Private.ValueBool()
 	publicVal := !plan.Public.IsNull() && !plan.Public.IsUnknown() && plan.Public.ValueBool()
@@ -287,17 +797,20 @@ func (r *linkResource) Create(ctx context.Context, req resource.CreateRequest, r
 	link.Hyphens = BoolToInt(hyphensVal)
 	link.Format = BoolToInt(formatVal)
 
-	link.Tags = append(link.Tags, plan.Tags...)
-
-	var aliases []string
-	if !plan.Aliases.IsNull() && !plan.Aliases.IsUnknown() {
-		diags := plan.Aliases.ElementsAs(ctx, &aliases, false)
+	var tags []string
+	if !plan.TagsAll.IsNull() && !plan.TagsAll.IsUnknown() {
+		diags := plan.TagsAll.ElementsAs(ctx, &tags, false)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
-	link.Aliases = aliases
+	link.Tags = tags
+
+	link.Aliases = r.aliasesForAPI(ctx, plan.Aliases, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var geolinks []client.Geolink
 	if !plan.Geolinks.IsNull() && !plan.Geolinks.IsUnknown() {
@@ -316,17 +829,56 @@ func (r *linkResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 	link.Geolinks = geolinks
 
-	// Create new link
-	linkresponse, err := r.client.CreateLink(ctx, link)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating link",
-			"Could not create link, unexpected error: "+err.Error(),
-		)
+	link.UID = r.ownerUID(ctx, plan.Owner, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	MapLinkResponseToModel(linkresponse, &plan, true)
+	adopt := r.settings.AdoptExistingLinks
+	if !plan.AdoptExisting.IsNull() {
+		adopt = plan.AdoptExisting.ValueBool()
+	}
+
+	link.Tags, link.Description = r.settings.Ownership.stamp(link.Tags, link.Description)
+
+	var linkresponse *client.GolinkResponse
+	var err error
+	if adopt {
+		linkresponse, err = r.adoptExisting(ctx, link, plan.OverrideOwnership.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error adopting link",
+				"Could not adopt existing link, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if linkresponse != nil {
+			resp.Diagnostics.AddWarning(
+				"Existing Link Adopted",
+				fmt.Sprintf("A link named %q already existed (gid %d). It was updated to match the configuration and is now managed by Terraform.", linkresponse.Name, linkresponse.Gid),
+			)
+		}
+	}
+
+	// Create new link
+	if linkresponse == nil {
+		linkresponse, err = r.client.CreateLink(ctx, link)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating link",
+				"Could not create link, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	configuredTags := plan.Tags
+	MapLinkResponseToModel(linkresponse, &plan, true, r.settings)
+	diags = r.settings.Defaults.removeFrom(ctx, &plan, configuredTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set state to fully populated data
 
@@ -335,6 +887,88 @@ func (r *linkResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = setIdentity(ctx, resp.Identity, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ownerUID resolves the configured owner, an email address or a uid, to the
+// uid sent to the API. It returns zero, leaving the owner unchanged, when no
+// owner is configured, and adds an error to diags when the owner is unknown
+// to GoLinks.
+func (r *linkResource) ownerUID(ctx context.Context, owner types.String, diags *diag.Diagnostics) int64 {
+	if owner.IsNull() || owner.IsUnknown() {
+		return 0
+	}
+
+	user, err := r.client.LookupUser(ctx, owner.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("owner"),
+			"Error resolving link owner",
+			"Could not find owner "+owner.ValueString()+": "+err.Error(),
+		)
+		return 0
+	}
+	return user.Uid
+}
+
+// aliasesForAPI returns the configured aliases with the provider name prefix
+// applied, so that aliases stay in the same namespace as the link names.
+func (r *linkResource) aliasesForAPI(ctx context.Context, aliases types.Set, diags *diag.Diagnostics) []string {
+	if aliases.IsNull() || aliases.IsUnknown() {
+		return nil
+	}
+
+	var names []string
+	diags.Append(aliases.ElementsAs(ctx, &names, false)...)
+	for i, name := range names {
+		names[i] = r.settings.qualifiedName(name)
+	}
+	return names
+}
+
+// adoptExisting takes over the link named like the requested one, updating it
+// to the requested values. It returns nil when no such link exists, and an
+// error when the link is owned by another workspace unless overrideOwnership.
+func (r *linkResource) adoptExisting(ctx context.Context, link client.CreateLinkRequest, overrideOwnership bool) (*client.GolinkResponse, error) {
+	existing, err := r.client.GetGolinksByName(ctx, link.Name)
+	if client.IsNotFound(err) || (err == nil && existing.Gid == 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.settings.Ownership.checkOwner(existing, overrideOwnership); err != nil {
+		return nil, err
+	}
+
+	tflog.Info(ctx, "Adopting existing link", map[string]interface{}{
+		"name": link.Name,
+		"gid":  existing.Gid,
+	})
+
+	_, err = r.client.UpdateLink(ctx, client.UpdateLinkRequest{
+		Gid:         existing.Gid,
+		URL:         link.URL,
+		Name:        link.Name,
+		Description: link.Description,
+		Tags:        appendIgnoredTags(link.Tags, existing.Tags, r.settings.IgnoreTags),
+		Unlisted:    link.Unlisted,
+		Private:     link.Private,
+		Public:      link.Public,
+		Format:      link.Format,
+		Hyphens:     link.Hyphens,
+		Aliases:     link.Aliases,
+		Geolinks:    link.Geolinks,
+		UID:         link.UID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.client.GetLink(ctx, strconv.FormatInt(existing.Gid, 10))
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -347,6 +981,15 @@ func (r *linkResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	linkresponse, err := r.client.GetLink(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -356,7 +999,26 @@ func (r *linkResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	MapLinkResponseToModel(linkresponse, &state, false)
+	configuredTags := state.Tags
+	MapLinkResponseToModel(linkresponse, &state, false, r.settings)
+	diags = r.settings.Defaults.removeFrom(ctx, &state, configuredTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ForceDestroyHeavilyUsed.IsNull() {
+		state.ForceDestroyHeavilyUsed = types.BoolValue(false)
+	}
+	if state.OverrideOwnership.IsNull() {
+		state.OverrideOwnership = types.BoolValue(false)
+	}
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(r.settings.DeletionProtection)
+	}
+	if state.DeletionPolicy.IsNull() {
+		state.DeletionPolicy = types.StringValue(deletionPolicyDelete)
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, state)
@@ -364,6 +1026,9 @@ func (r *linkResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = setIdentity(ctx, resp.Identity, state)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -383,11 +1048,20 @@ func (r *linkResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var link client.UpdateLinkRequest
 	link.Gid = state.Gid.ValueInt64()
 	link.URL = plan.URL.ValueString()
-	link.Name = plan.Name.ValueString()
-	link.Description = plan.Description.ValueString()
+	link.Name = linkNameForAPI(plan, r.settings)
+	link.Description = plan.DescriptionAll.ValueString()
 
 	privateVal := !plan.Private.IsNull() && !plan.Private.IsUnknown() && plan.Private.ValueBool()
 	publicVal := !plan.Public.IsNull() && !plan.Public.IsUnknown() && plan.Public.ValueBool()
@@ -407,18 +1081,41 @@ func (r *linkResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	link.Hyphens = BoolToInt(hyphensVal)
 
 	var tags []string
-	tags = append(tags, plan.Tags...)
-	link.Tags = tags
-
-	var aliases []string
-	if !plan.Aliases.IsNull() && !plan.Aliases.IsUnknown() {
-		diags := plan.Aliases.ElementsAs(ctx, &aliases, false)
+	if !plan.TagsAll.IsNull() && !plan.TagsAll.IsUnknown() {
+		diags := plan.TagsAll.ElementsAs(ctx, &tags, false)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
-	link.Aliases = aliases
+	link.Tags = tags
+
+	// Refuse to update links owned by another workspace, and keep the tags
+	// managed outside of Terraform.
+	if r.settings.IgnoreTags.enabled() || r.settings.Ownership.enabled() {
+		current, err := r.client.GetLink(ctx, strconv.FormatInt(link.Gid, 10))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating GoLink",
+				"Could not read the current link, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if err := r.settings.Ownership.checkOwner(current, plan.OverrideOwnership.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Link Owned By Another Workspace",
+				"Could not update link: "+err.Error(),
+			)
+			return
+		}
+		link.Tags = appendIgnoredTags(link.Tags, current.Tags, r.settings.IgnoreTags)
+	}
+	link.Tags, link.Description = r.settings.Ownership.stamp(link.Tags, link.Description)
+
+	link.Aliases = r.aliasesForAPI(ctx, plan.Aliases, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var geolinks []client.Geolink
 	if !plan.Geolinks.IsNull() && !plan.Geolinks.IsUnknown() {
@@ -437,6 +1134,11 @@ func (r *linkResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 	link.Geolinks = geolinks
 
+	link.UID = r.ownerUID(ctx, plan.Owner, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Log the link structure
 	linkJSON, _ := json.MarshalIndent(link, "", "  ")
 	tflog.Debug(ctx, "UpdateLinkRequest structure", map[string]interface{}{
@@ -463,13 +1165,30 @@ func (r *linkResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	MapLinkResponseToModel(linkresponse, &plan, true)
+	// The planned redirect hits come from state, so keep them rather than
+	// the live counters to avoid an inconsistent result. Read refreshes them.
+	redirectHits := plan.RedirectHits
+
+	configuredTags := plan.Tags
+	MapLinkResponseToModel(linkresponse, &plan, true, r.settings)
+	diags = r.settings.Defaults.removeFrom(ctx, &plan, configuredTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !redirectHits.IsUnknown() {
+		plan.RedirectHits = redirectHits
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = setIdentity(ctx, resp.Identity, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -481,6 +1200,51 @@ func (r *linkResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Link Is Protected From Deletion",
+			fmt.Sprintf("The link %q has deletion_protection enabled. Set `deletion_protection = false` and apply that change before deleting it.", state.Name.ValueString()),
+		)
+		return
+	}
+
+	if state.DeletionPolicy.ValueString() == deletionPolicyAbandon {
+		tflog.Info(ctx, "Abandoning link, it is removed from state but left in GoLinks", map[string]interface{}{
+			"name": state.Name.ValueString(),
+			"gid":  state.Gid.ValueInt64(),
+		})
+		return
+	}
+
+	if r.settings.Ownership.enabled() {
+		current, err := r.client.GetLink(ctx, strconv.FormatInt(state.Gid.ValueInt64(), 10))
+		if err != nil && !client.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Golink",
+				"Could not read the current link, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if err == nil {
+			if err := r.settings.Ownership.checkOwner(current, state.OverrideOwnership.ValueBool()); err != nil {
+				resp.Diagnostics.AddError(
+					"Link Owned By Another Workspace",
+					"Could not delete link: "+err.Error(),
+				)
+				return
+			}
+		}
+	}
+
 	// Delete existing order
 	err := r.client.DeleteLink(ctx, state.Gid.ValueInt64())
 	if err != nil {
@@ -490,11 +1254,183 @@ func (r *linkResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		)
 		return
 	}
+
+	tflog.Info(ctx, "Deleted link", map[string]interface{}{
+		"name": state.Name.ValueString(),
+		"gid":  state.Gid.ValueInt64(),
+	})
 }
 
+// ImportState accepts a numeric gid, "name:<name>", "alias:<alias>" or a go
+// link such as "go/<name>", and stores the canonical gid as the id. Import
+// blocks may instead provide an identity with a cid and either a gid or name.
 func (r *linkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID == "" && req.Identity != nil {
+		r.importStateByIdentity(ctx, req, resp)
+		return
+	}
+
+	gid, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		if name, ok := strings.CutPrefix(req.ID, "name:"); ok {
+			gid, err = r.lookupGid(ctx, r.settings.qualifiedName(name), true, false)
+		} else if alias, ok := strings.CutPrefix(req.ID, "alias:"); ok {
+			gid, err = r.lookupGid(ctx, r.settings.qualifiedName(alias), false, true)
+		} else if name, ok := GoLinkNameFromURL(req.ID); ok {
+			gid, err = r.lookupGid(ctx, r.settings.qualifiedName(name), true, true)
+		} else {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Expected a numeric gid, \"name:<name>\", \"alias:<alias>\" or \"go/<name>\", got: %q", req.ID),
+			)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing link",
+				fmt.Sprintf("Could not resolve import ID %q: %s", req.ID, err.Error()),
+			)
+			return
+		}
+	}
+
+	if !r.checkImportNamespace(ctx, gid, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Resolved import ID", map[string]interface{}{
+		"import_id": req.ID,
+		"gid":       gid,
+	})
+
+	diags := resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(gid, 10))
+	resp.Diagnostics.Append(diags...)
+}
+
+// importStateByIdentity resolves the link described by an import identity.
+func (r *linkResource) importStateByIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity linkIdentityModel
+	diags := req.Identity.Get(ctx, &identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var linkresponse *client.GolinkResponse
+	var err error
+	switch {
+	case !identity.Gid.IsNull() && identity.Gid.ValueInt64() != 0:
+		linkresponse, err = r.client.GetLink(ctx, strconv.FormatInt(identity.Gid.ValueInt64(), 10))
+	case !identity.Name.IsNull() && identity.Name.ValueString() != "":
+		linkresponse, err = r.client.GetGolinksByName(ctx, r.settings.qualifiedName(identity.Name.ValueString()))
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid Import Identity",
+			"The import identity must set either `gid` or `name` to identify the link.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing link",
+			"Could not get link, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if !r.settings.ownsName(linkresponse.Name) {
+		resp.Diagnostics.AddError(
+			"Link Outside Name Prefixes",
+			fmt.Sprintf("The link %q is outside the namespace of this provider, which only manages links starting with its name_prefix and allowed_name_prefixes.", linkresponse.Name),
+		)
+		return
+	}
+
+	if linkresponse.Cid != identity.Cid.ValueInt64() {
+		resp.Diagnostics.AddError(
+			"Invalid Import Identity",
+			fmt.Sprintf("The link %d belongs to company %d, not to company %d.", linkresponse.Gid, linkresponse.Cid, identity.Cid.ValueInt64()),
+		)
+		return
+	}
+
+	diags = resp.State.SetAttribute(ctx, path.Root("id"), strconv.FormatInt(linkresponse.Gid, 10))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Identity.Set(ctx, linkIdentityModel{
+		Cid:  types.Int64Value(linkresponse.Cid),
+		Gid:  types.Int64Value(linkresponse.Gid),
+		Name: types.StringNull(),
+	})
+	resp.Diagnostics.Append(diags...)
+}
+
+// checkImportNamespace reports whether the link gid may be imported, adding
+// an error to diags when its name lies outside the provider namespace.
+func (r *linkResource) checkImportNamespace(ctx context.Context, gid int64, diags *diag.Diagnostics) bool {
+	if r.settings.NamePrefix == "" && len(r.settings.AllowedNamePrefixes) == 0 {
+		return true
+	}
+
+	link, err := r.client.GetLink(ctx, strconv.FormatInt(gid, 10))
+	if err != nil {
+		diags.AddError(
+			"Error importing link",
+			"Could not get link, unexpected error: "+err.Error(),
+		)
+		return false
+	}
+
+	if !r.settings.ownsName(link.Name) {
+		diags.AddError(
+			"Link Outside Name Prefixes",
+			fmt.Sprintf("The link %q is outside the namespace of this provider, which only manages links starting with its name_prefix and allowed_name_prefixes.", link.Name),
+		)
+		return false
+	}
+	return true
+}
+
+// lookupGid resolves key as a link name, an alias, or both. When both are
+// allowed and they resolve to different links, the key is ambiguous.
+func (r *linkResource) lookupGid(ctx context.Context, key string, byName, byAlias bool) (int64, error) {
+	var gids []int64
+	var errs []string
+
+	if byName {
+		link, err := r.client.GetGolinksByName(ctx, key)
+		if err == nil && link.Gid != 0 {
+			gids = append(gids, link.Gid)
+		} else if err != nil {
+			errs = append(errs, "name lookup: "+err.Error())
+		}
+	}
+
+	if byAlias {
+		link, err := r.client.GetGolinksByAlias(ctx, key)
+		if err == nil && link.Gid != 0 {
+			if len(gids) == 0 || gids[0] != link.Gid {
+				gids = append(gids, link.Gid)
+			}
+		} else if err != nil {
+			errs = append(errs, "alias lookup: "+err.Error())
+		}
+	}
+
+	switch len(gids) {
+	case 0:
+		if len(errs) > 0 {
+			return 0, fmt.Errorf("no link found for %q (%s)", key, strings.Join(errs, "; "))
+		}
+		return 0, fmt.Errorf("no link found for %q", key)
+	case 1:
+		return gids[0], nil
+	default:
+		return 0, fmt.Errorf("%q is ambiguous: it is the name of link %d and an alias of link %d; use \"name:%s\" or \"alias:%s\" instead", key, gids[0], gids[1], key, key)
+	}
 }
 
 // Configure adds the provider configured client to the resource.
@@ -505,16 +1441,18 @@ func (r *linkResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *golinks.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.settings = data.Settings
+	r.lookups = data.Lookups
 }
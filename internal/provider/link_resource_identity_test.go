@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strconv"
+	"testing"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestLinkResourceIdentity(t *testing.T) {
+	server := newTestServer(t)
+	existing := server.addLink(client.GolinkResponse{
+		Name:        "existing",
+		URL:         "https://example.com",
+		Description: "Link created outside of Terraform",
+	})
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create populates the identity
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "testlink-identity"
+  url         = "https://google.com"
+  description = "Link with an identity"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("golinks_link.test", map[string]knownvalue.Check{
+						"cid":  knownvalue.Int64Exact(testServerCid),
+						"gid":  knownvalue.NotNull(),
+						"name": knownvalue.Null(),
+					}),
+					statecheck.ExpectIdentityValueMatchesStateAtPath("golinks_link.test", tfjsonpath.New("gid"), tfjsonpath.New("gid")),
+				},
+			},
+			// Import by identity using the cid and gid
+			{
+				ResourceName:    "golinks_link.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			// Import by identity using the name as a secondary lookup, which
+			// adopts the link as it is without planning any change.
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "testlink-identity"
+  url         = "https://google.com"
+  description = "Link with an identity"
+}
+
+import {
+  to = golinks_link.existing
+  identity = {
+    cid  = 1
+    name = "existing"
+  }
+}
+
+resource "golinks_link" "existing" {
+  name        = "existing"
+  url         = "https://example.com"
+  description = "Link created outside of Terraform"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("golinks_link.existing", plancheck.ResourceActionNoop),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.existing", "gid", strconv.FormatInt(existing.Gid, 10)),
+					resource.TestCheckResourceAttr("golinks_link.existing", "id", strconv.FormatInt(existing.Gid, 10)),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("golinks_link.existing", map[string]knownvalue.Check{
+						"cid":  knownvalue.Int64Exact(testServerCid),
+						"gid":  knownvalue.Int64Exact(existing.Gid),
+						"name": knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// UpgradeState upgrades the state of earlier schema versions.
+func (r *linkResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored tags and aliases as lists.
+		0: {
+			PriorSchema:   &linkSchemaV0,
+			StateUpgrader: upgradeLinkStateV0,
+		},
+	}
+}
+
+// linkSchemaV0 is the last schema of version 0. It is frozen: changes to the
+// current schema must not change how version 0 state is decoded. Only the
+// types matter, so descriptions, defaults and plan modifiers are left out.
+var linkSchemaV0 = schema.Schema{
+	Version: 0,
+	Blocks: map[string]schema.Block{
+		"timeouts": schema.SingleNestedBlock{
+			Attributes: map[string]schema.Attribute{
+				"create": schema.StringAttribute{Optional: true},
+				"read":   schema.StringAttribute{Optional: true},
+				"update": schema.StringAttribute{Optional: true},
+				"delete": schema.StringAttribute{Optional: true},
+			},
+		},
+	},
+	Attributes: map[string]schema.Attribute{
+		"id":            schema.StringAttribute{Computed: true},
+		"gid":           schema.Int64Attribute{Computed: true},
+		"last_updated":  schema.StringAttribute{Computed: true},
+		"variable_link": schema.BoolAttribute{Computed: true},
+		"pinned":        schema.BoolAttribute{Computed: true},
+		"cid":           schema.Int64Attribute{Computed: true},
+		"url":           schema.StringAttribute{Required: true},
+		"name":          schema.StringAttribute{Required: true},
+		"description":   schema.StringAttribute{Required: true},
+		"tags":          schema.ListAttribute{Optional: true, ElementType: types.StringType},
+		"unlisted":      schema.BoolAttribute{Optional: true, Computed: true},
+		"private":       schema.BoolAttribute{Optional: true, Computed: true},
+		"public":        schema.BoolAttribute{Optional: true, Computed: true},
+		"format":        schema.BoolAttribute{Optional: true, Computed: true},
+		"hyphens":       schema.BoolAttribute{Optional: true, Computed: true},
+		"aliases":       schema.ListAttribute{Optional: true, ElementType: types.StringType},
+		"geolinks": schema.ListNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"location": schema.StringAttribute{Required: true},
+					"url":      schema.StringAttribute{Required: true},
+				},
+			},
+		},
+		"created_at": schema.Int64Attribute{Computed: true},
+		"updated_at": schema.Int64Attribute{Computed: true},
+		"user": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"uid":            schema.Int64Attribute{Computed: true},
+				"first_name":     schema.StringAttribute{Computed: true},
+				"last_name":      schema.StringAttribute{Computed: true},
+				"username":       schema.StringAttribute{Computed: true},
+				"email":          schema.StringAttribute{Computed: true},
+				"user_image_url": schema.StringAttribute{Computed: true},
+			},
+		},
+		"adopt_existing":      schema.BoolAttribute{Optional: true},
+		"deletion_protection": schema.BoolAttribute{Optional: true, Computed: true},
+		"deletion_policy":     schema.StringAttribute{Optional: true, Computed: true},
+		"redirect_hits": schema.SingleNestedAttribute{
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"daily":   schema.Int64Attribute{Computed: true},
+				"weekly":  schema.Int64Attribute{Computed: true},
+				"monthly": schema.Int64Attribute{Computed: true},
+				"alltime": schema.Int64Attribute{Computed: true},
+			},
+		},
+		"owner":                      schema.StringAttribute{Optional: true},
+		"force_destroy_heavily_used": schema.BoolAttribute{Optional: true, Computed: true},
+	},
+}
+
+// upgradeLinkStateV0 converts the tags and aliases lists to sets, dropping
+// duplicate entries which a set cannot hold. Attributes added after version 0
+// start out null and are filled by the next refresh.
+func upgradeLinkStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var attrs map[string]tftypes.Value
+	if err := req.State.Raw.As(&attrs); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"Could not read the version 0 state of the link: "+err.Error(),
+		)
+		return
+	}
+
+	for _, name := range []string{"tags", "aliases"} {
+		set, err := listToStringSet(attrs[name])
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unable to Upgrade Resource State",
+				"Could not convert the list to a set: "+err.Error(),
+			)
+			return
+		}
+		attrs[name] = set
+	}
+
+	stateType := resp.State.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	upgraded := make(map[string]tftypes.Value, len(stateType.AttributeTypes))
+	for name, attrType := range stateType.AttributeTypes {
+		if value, ok := attrs[name]; ok {
+			upgraded[name] = value
+		} else {
+			upgraded[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	resp.State.Raw = tftypes.NewValue(stateType, upgraded)
+}
+
+// listToStringSet converts a list of strings to a set of strings.
+func listToStringSet(list tftypes.Value) (tftypes.Value, error) {
+	setType := tftypes.Set{ElementType: tftypes.String}
+	if list.IsNull() {
+		return tftypes.NewValue(setType, nil), nil
+	}
+
+	var elems []tftypes.Value
+	if err := list.As(&elems); err != nil {
+		return tftypes.Value{}, err
+	}
+
+	seen := map[string]bool{}
+	unique := make([]tftypes.Value, 0, len(elems))
+	for _, elem := range elems {
+		var s string
+		if err := elem.As(&s); err != nil {
+			return tftypes.Value{}, err
+		}
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		unique = append(unique, elem)
+	}
+	return tftypes.NewValue(setType, unique), nil
+}
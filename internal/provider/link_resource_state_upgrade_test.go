@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestLinkResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	raw, err := os.ReadFile("testdata/link_state_v0.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Upgrade the raw state the way Terraform does, through the protocol.
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgradeResp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "golinks_link",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: raw},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diag := range upgradeResp.Diagnostics {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected diagnostic: %s: %s", diag.Summary, diag.Detail)
+		}
+	}
+
+	var schemaResp resource.SchemaResponse
+	(&linkResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	upgraded, err := upgradeResp.UpgradedState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("decoding upgraded state: %s", err)
+	}
+
+	var state linkResourceModel
+	if diags := (tfsdk.State{Schema: schemaResp.Schema, Raw: upgraded}).Get(ctx, &state); diags.HasError() {
+		t.Fatalf("reading upgraded state: %v", diags)
+	}
+
+	var tags []string
+	if diags := state.Tags.ElementsAs(ctx, &tags, false); diags.HasError() {
+		t.Fatalf("reading tags: %v", diags)
+	}
+	slices.Sort(tags)
+	if want := []string{"docs", "testing"}; !slices.Equal(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+
+	if !state.Aliases.IsNull() {
+		t.Errorf("aliases = %v, want null", state.Aliases)
+	}
+	if state.Name.ValueString() != "example" {
+		t.Errorf("name = %q, want %q", state.Name.ValueString(), "example")
+	}
+	// Attributes added after version 0 are filled by the next refresh.
+	if !state.NormalizedName.IsNull() || !state.TagsAll.IsNull() {
+		t.Errorf("normalized_name = %v, tags_all = %v, want null", state.NormalizedName, state.TagsAll)
+	}
+}
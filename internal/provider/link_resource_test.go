@@ -4,9 +4,16 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
 	"testing"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestLinkResource(t *testing.T) {
@@ -32,6 +39,8 @@ resource "golinks_link" "test" {
 					resource.TestCheckResourceAttr("golinks_link.test", "private", "false"),
 					resource.TestCheckResourceAttr("golinks_link.test", "public", "false"),
 					resource.TestCheckResourceAttr("golinks_link.test", "unlisted", "false"),
+					resource.TestCheckResourceAttr("golinks_link.test", "force_destroy_heavily_used", "false"),
+					resource.TestCheckResourceAttrSet("golinks_link.test", "redirect_hits.monthly"),
 					resource.TestCheckNoResourceAttr("golinks_link.test", "aliases"),
 					resource.TestCheckNoResourceAttr("golinks_link.test", "geolinks"),
 					resource.TestCheckNoResourceAttr("golinks_link.test", "multilinks"),
@@ -44,7 +53,24 @@ resource "golinks_link" "test" {
 				ImportStateVerify: true,
 				// The last_updated attribute does not exist in the Golinks
 				// API, therefore there is no value for it during import.
-				ImportStateVerifyIgnore: []string{"last_updated", "private", "public"},
+				// Redirect hits may move between the apply and the import.
+				ImportStateVerifyIgnore: []string{"last_updated", "redirect_hits"},
+			},
+			// ImportState by name testing
+			{
+				ResourceName:            "golinks_link.test",
+				ImportState:             true,
+				ImportStateId:           "name:testlink",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "redirect_hits"},
+			},
+			// ImportState by go link testing
+			{
+				ResourceName:            "golinks_link.test",
+				ImportState:             true,
+				ImportStateId:           "go/testlink",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "redirect_hits"},
 			},
 			// Update and Read testing
 			{
@@ -108,8 +134,8 @@ resource "golinks_link" "private_only" {
 					resource.TestCheckResourceAttr("golinks_link.options", "hyphens", "false"),
 
 					resource.TestCheckResourceAttr("golinks_link.options", "tags.#", "2"),
-					resource.TestCheckResourceAttr("golinks_link.options", "tags.0", "testing"),
-					resource.TestCheckResourceAttr("golinks_link.options", "tags.1", "tag2"),
+					resource.TestCheckTypeSetElemAttr("golinks_link.options", "tags.*", "testing"),
+					resource.TestCheckTypeSetElemAttr("golinks_link.options", "tags.*", "tag2"),
 					resource.TestCheckResourceAttr("golinks_link.private_only", "private", "true"),
 					resource.TestCheckResourceAttr("golinks_link.private_only", "public", "false"),
 					resource.TestCheckResourceAttr("golinks_link.private_only", "unlisted", "true"),
@@ -118,3 +144,836 @@ resource "golinks_link" "private_only" {
 		},
 	})
 }
+
+func TestLinkResourceImport(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Team wiki"
+  aliases     = ["kb"]
+}
+`,
+			},
+			{
+				ResourceName:            "golinks_link.wiki",
+				ImportState:             true,
+				ImportStateId:           "alias:kb",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "redirect_hits"},
+			},
+			{
+				ResourceName:  "golinks_link.wiki",
+				ImportState:   true,
+				ImportStateId: "alias:handbook",
+				ExpectError:   regexp.MustCompile(`no link found for "handbook"`),
+			},
+			// "kb" is now both the name of a link and an alias of another.
+			{
+				PreConfig: func() {
+					server.addLink(client.GolinkResponse{Name: "kb", URL: "https://kb.example.com"})
+				},
+				ResourceName:  "golinks_link.wiki",
+				ImportState:   true,
+				ImportStateId: "go/kb",
+				ExpectError:   regexp.MustCompile(`"kb" is ambiguous: it is the name of link \d+ and an alias of link \d+`),
+			},
+		},
+	})
+}
+
+func TestLinkResourceOwner(t *testing.T) {
+	server := newTestServer(t)
+	linkConfig := func(owner string) string {
+		return server.providerConfig() + fmt.Sprintf(`
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Team wiki"
+  owner       = %q
+}
+`, owner)
+	}
+	checkOwnerUid := func(uid int64) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := server.linkByName("wiki").User.Uid; got != uid {
+				return fmt.Errorf("expected the link to be owned by uid %d, got %d", uid, got)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Owner set by email.
+			{
+				Config: linkConfig("other@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.wiki", "owner", "other@example.com"),
+					resource.TestCheckResourceAttr("golinks_link.wiki", "user.uid", "2"),
+					checkOwnerUid(2),
+				),
+			},
+			// Owner set by uid transfers the link.
+			{
+				Config: linkConfig("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.wiki", "owner", "1"),
+					resource.TestCheckResourceAttr("golinks_link.wiki", "user.uid", "1"),
+					checkOwnerUid(1),
+				),
+			},
+			// A transfer made outside of Terraform shows up as drift.
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					defer server.mu.Unlock()
+
+					server.links[server.nextGid].User = server.users[1]
+				},
+				Config:             linkConfig("1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying the configuration transfers the link back.
+			{
+				Config: linkConfig("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.wiki", "owner", "1"),
+					checkOwnerUid(1),
+				),
+			},
+			{
+				Config:      linkConfig("nobody@example.com"),
+				ExpectError: regexp.MustCompile(`Could not find owner nobody@example.com`),
+			},
+		},
+	})
+}
+
+func TestLinkResourceAdoptExisting(t *testing.T) {
+	server := newTestServer(t)
+	existing := server.addLink(client.GolinkResponse{
+		Name:        "adopted",
+		URL:         "https://old.example.com",
+		Description: "Link created outside of Terraform",
+	})
+	server.aliases["adopted-old"] = existing.Gid
+	server.addLink(client.GolinkResponse{Name: "other", URL: "https://other.example.com"})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Only the aliases of the adopted link may be re-declared.
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name           = "adopted"
+  url            = "https://new.example.com"
+  description    = "Link adopted by Terraform"
+  aliases        = ["adopted-old", "other"]
+  adopt_existing = true
+}
+`,
+				ExpectError: regexp.MustCompile(`"other" is already used by link`),
+			},
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name           = "adopted"
+  url            = "https://new.example.com"
+  description    = "Link adopted by Terraform"
+  aliases        = ["adopted-old"]
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.test", "gid", strconv.FormatInt(existing.Gid, 10)),
+					resource.TestCheckResourceAttr("golinks_link.test", "url", "https://new.example.com"),
+					resource.TestCheckResourceAttr("golinks_link.test", "description", "Link adopted by Terraform"),
+				),
+			},
+		},
+	})
+}
+
+func TestLinkResourceFormat(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "Team Wiki!"
+  url         = "https://wiki.example.com"
+  description = "Link with a formatted name"
+  format      = true
+  hyphens     = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.test", "name", "Team Wiki!"),
+					resource.TestCheckResourceAttr("golinks_link.test", "normalized_name", "Team-Wiki"),
+					resource.TestCheckResourceAttr("golinks_link.test", "format", "true"),
+					resource.TestCheckResourceAttr("golinks_link.test", "hyphens", "true"),
+				),
+			},
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "Team Wiki!"
+  url         = "https://wiki.example.com"
+  description = "Link with a formatted name"
+  format      = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.test", "name", "Team Wiki!"),
+					resource.TestCheckResourceAttr("golinks_link.test", "normalized_name", "TeamWiki"),
+				),
+			},
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "Team Wiki"
+  url         = "https://wiki.example.com"
+  description = "Link with a formatted name"
+  hyphens     = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Hyphens Requires Format`),
+			},
+		},
+	})
+}
+
+func TestLinkResourceProviderDefaults(t *testing.T) {
+	server := newTestServer(t)
+	providerConfig := fmt.Sprintf(`
+provider "golinks" {
+  host  = %q
+  token = %q
+
+  defaults {
+    tags               = ["terraform", "docs"]
+    unlisted           = true
+    description_suffix = " (go/{name})"
+  }
+}
+`, server.URL, testServerToken)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "golinks_link" "test" {
+  name        = "handbook"
+  url         = "https://handbook.example.com"
+  description = "Employee handbook"
+  tags        = ["docs", "hr"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.test", "description", "Employee handbook"),
+					resource.TestCheckResourceAttr("golinks_link.test", "description_all", "Employee handbook (go/handbook)"),
+					resource.TestCheckResourceAttr("golinks_link.test", "unlisted", "true"),
+					resource.TestCheckResourceAttr("golinks_link.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("golinks_link.test", "tags_all.#", "3"),
+					resource.TestCheckTypeSetElemAttr("golinks_link.test", "tags_all.*", "terraform"),
+					resource.TestCheckTypeSetElemAttr("golinks_link.test", "tags_all.*", "docs"),
+					resource.TestCheckTypeSetElemAttr("golinks_link.test", "tags_all.*", "hr"),
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "golinks_link" "test" {
+  name        = "handbook"
+  url         = "https://handbook.example.com"
+  description = "Employee handbook"
+  unlisted    = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("golinks_link.test", "tags.#"),
+					resource.TestCheckResourceAttr("golinks_link.test", "tags_all.#", "2"),
+					resource.TestCheckResourceAttr("golinks_link.test", "unlisted", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestLinkResourceIgnoreTags(t *testing.T) {
+	server := newTestServer(t)
+	providerConfig := fmt.Sprintf(`
+provider "golinks" {
+  host  = %q
+  token = %q
+
+  ignore_tags {
+    names         = ["featured"]
+    name_prefixes = ["team:"]
+  }
+}
+`, server.URL, testServerToken)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "golinks_link" "test" {
+  name        = "roadmap"
+  url         = "https://roadmap.example.com"
+  description = "Product roadmap"
+  tags        = ["product"]
+}
+`,
+				Check: resource.TestCheckResourceAttr("golinks_link.test", "tags.#", "1"),
+			},
+			{
+				PreConfig: func() {
+					link := server.linkByName("roadmap")
+					link.Tags = append(link.Tags,
+						client.TagResponse{Tid: 10, Name: "featured"},
+						client.TagResponse{Tid: 11, Name: "team:product"},
+					)
+				},
+				Config: providerConfig + `
+resource "golinks_link" "test" {
+  name        = "roadmap"
+  url         = "https://roadmap.example.com/2026"
+  description = "Product roadmap"
+  tags        = ["product"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("golinks_link.test", "tags_all.#", "1"),
+					func(*terraform.State) error {
+						if got := len(server.linkByName("roadmap").Tags); got != 3 {
+							return fmt.Errorf("expected the ignored tags to survive the update, got %d tags", got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestLinkResourceNamePrefix(t *testing.T) {
+	server := newTestServer(t)
+	deck := server.addLink(client.GolinkResponse{Name: "sales/deck", URL: "https://deck.example.com"})
+	providerConfig := fmt.Sprintf(`
+provider "golinks" {
+  host                  = %q
+  token                 = %q
+  name_prefix           = "payments/"
+  allowed_name_prefixes = ["payments/"]
+}
+`, server.URL, testServerToken)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "golinks_link" "test" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Payments wiki"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.test", "name", "wiki"),
+					resource.TestCheckResourceAttr("golinks_link.test", "normalized_name", "payments/wiki"),
+				),
+			},
+			{
+				ResourceName:            "golinks_link.test",
+				ImportState:             true,
+				ImportStateId:           "name:wiki",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "redirect_hits"},
+			},
+			{
+				Config: providerConfig + `
+resource "golinks_link" "test" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Payments wiki"
+  aliases     = ["kb"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("golinks_link.test", "aliases.*", "kb"),
+					func(_ *terraform.State) error {
+						server.mu.Lock()
+						defer server.mu.Unlock()
+
+						if _, ok := server.aliases["payments/kb"]; !ok {
+							return fmt.Errorf("alias was not created with the name prefix, got: %v", server.aliases)
+						}
+						return nil
+					},
+				),
+			},
+			// Names given on import get the prefix, so a link of another
+			// namespace can only be reached by its gid, which is refused.
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "golinks_link" "test" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Payments wiki"
+}
+
+import {
+  to = golinks_link.deck
+  id = "%d"
+}
+
+resource "golinks_link" "deck" {
+  name        = "deck"
+  url         = "https://deck.example.com"
+  description = ""
+}
+`, deck.Gid),
+				ExpectError: regexp.MustCompile(`Link Outside Name Prefixes`),
+			},
+		},
+	})
+
+	// Without a name prefix, aliases must start with an allowed prefix.
+	allowedConfig := fmt.Sprintf(`
+provider "golinks" {
+  host                  = %q
+  token                 = %q
+  allowed_name_prefixes = ["payments/"]
+}
+`, server.URL, testServerToken)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: allowedConfig + `
+resource "golinks_link" "test" {
+  name        = "payments/handbook"
+  url         = "https://handbook.example.com"
+  description = "Payments handbook"
+  aliases     = ["sales/handbook"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Alias Outside Allowed Prefixes`),
+			},
+		},
+	})
+}
+
+func TestLinkResourceReadOnly(t *testing.T) {
+	server := newTestServer(t)
+	readOnlyConfig := fmt.Sprintf(`
+provider "golinks" {
+  host      = %q
+  token     = %q
+  read_only = true
+}
+`, server.URL, testServerToken)
+	linkConfig := func(url string) string {
+		return fmt.Sprintf(`
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = %q
+  description = "Team wiki"
+}
+
+data "golinks_link" "wiki" {
+  name = golinks_link.wiki.name
+}
+`, url)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + linkConfig("https://wiki.example.com"),
+			},
+			{
+				Config: readOnlyConfig + linkConfig("https://wiki.example.com"),
+				Check:  resource.TestCheckResourceAttr("data.golinks_link.wiki", "url", "https://wiki.example.com"),
+			},
+			{
+				Config:      readOnlyConfig + linkConfig("https://wiki.example.com/home"),
+				ExpectError: regexp.MustCompile(`The link cannot be updated as the provider is configured with`),
+			},
+			{
+				Config:      readOnlyConfig,
+				ExpectError: regexp.MustCompile(`The link cannot be destroyed as the provider is configured with`),
+			},
+			{
+				Config: server.providerConfig() + linkConfig("https://wiki.example.com"),
+			},
+		},
+	})
+}
+
+func TestLinkResourceOwnershipMarker(t *testing.T) {
+	server := newTestServer(t)
+	server.addLink(client.GolinkResponse{
+		Name:        "handbook",
+		URL:         "https://handbook.example.com",
+		Description: "Employee handbook",
+		Tags:        []client.TagResponse{{Tid: 1, Name: "managed-by:people-ops"}},
+	})
+	providerConfig := fmt.Sprintf(`
+provider "golinks" {
+  host  = %q
+  token = %q
+
+  ownership_marker {
+    workspace = "payments-prod"
+  }
+}
+`, server.URL, testServerToken)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Payments wiki"
+  tags        = ["docs"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.wiki", "tags.#", "1"),
+					resource.TestCheckResourceAttr("golinks_link.wiki", "tags_all.#", "1"),
+					func(*terraform.State) error {
+						for _, tag := range server.linkByName("wiki").Tags {
+							if tag.Name == "managed-by:payments-prod" {
+								return nil
+							}
+						}
+						return fmt.Errorf("expected the link to be stamped with the ownership tag")
+					},
+				),
+			},
+			{
+				Config: providerConfig + `
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Payments wiki"
+  tags        = ["docs"]
+}
+
+resource "golinks_link" "handbook" {
+  name           = "handbook"
+  url            = "https://payments.example.com/handbook"
+  description    = "Payments handbook"
+  adopt_existing = true
+}
+`,
+				ExpectError: regexp.MustCompile(`managed by the Terraform workspace "people-ops"`),
+			},
+			{
+				Config: providerConfig + `
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Payments wiki"
+  tags        = ["docs"]
+}
+
+resource "golinks_link" "handbook" {
+  name               = "handbook"
+  url                = "https://payments.example.com/handbook"
+  description        = "Payments handbook"
+  adopt_existing     = true
+  override_ownership = true
+}
+`,
+				Check: resource.TestCheckResourceAttr("golinks_link.handbook", "url", "https://payments.example.com/handbook"),
+			},
+		},
+	})
+}
+
+func TestLinkResourceNameCollision(t *testing.T) {
+	server := newTestServer(t)
+	server.addLink(client.GolinkResponse{
+		Name:        "taken",
+		URL:         "https://example.com",
+		Description: "Link created outside of Terraform",
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "taken"
+  url         = "https://google.com"
+  description = "Link colliding with an existing name"
+}
+`,
+				ExpectError: regexp.MustCompile(`Name Already In Use`),
+			},
+		},
+	})
+}
+
+func TestLinkResourceDeletionProtection(t *testing.T) {
+	server := newTestServer(t)
+
+	protected := server.providerConfig() + `
+resource "golinks_link" "test" {
+  name                = "incident"
+  url                 = "https://google.com"
+  description         = "Critical link"
+  deletion_protection = true
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: protected,
+				Check:  resource.TestCheckResourceAttr("golinks_link.test", "deletion_protection", "true"),
+			},
+			{
+				Config:      protected,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Link Is Protected From Deletion`),
+			},
+			// Replacing the link would delete it as well.
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name                = "incident"
+  url                 = "https://google.com"
+  description         = "Critical link"
+  deletion_protection = true
+  private             = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Link Is Protected From Deletion`),
+			},
+			// Lift the protection so the link can be destroyed.
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name                = "incident"
+  url                 = "https://google.com"
+  description         = "Critical link"
+  deletion_protection = false
+}
+`,
+				Check: resource.TestCheckResourceAttr("golinks_link.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestLinkResourceDestroyGuard(t *testing.T) {
+	server := newTestServer(t)
+	providerConfig := fmt.Sprintf(`
+provider "golinks" {
+  host                       = %q
+  token                      = %q
+  destroy_guard_monthly_hits = 100
+}
+`, server.URL, testServerToken)
+	wikiConfig := func(attributes string) string {
+		return fmt.Sprintf(`
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Team wiki"
+%s
+}
+`, attributes)
+	}
+	handbookConfig := `
+resource "golinks_link" "handbook" {
+  name            = "handbook"
+  url             = "https://handbook.example.com"
+  description     = "Employee handbook"
+  deletion_policy = "abandon"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + wikiConfig("") + handbookConfig,
+			},
+			// Replacing a heavily used link fails.
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					defer server.mu.Unlock()
+
+					for _, link := range server.links {
+						link.RedirectHits.Monthly = 500
+					}
+				},
+				Config:      providerConfig + wikiConfig("  private = true") + handbookConfig,
+				ExpectError: regexp.MustCompile(`Heavily Used Link Cannot Be Destroyed`),
+			},
+			// Destroying a heavily used link fails, while abandoning one does
+			// not destroy it and is allowed.
+			{
+				Config:      providerConfig + handbookConfig,
+				ExpectError: regexp.MustCompile(`was hit 500 times in the last month`),
+			},
+			{
+				Config: providerConfig + wikiConfig(""),
+				Check: func(*terraform.State) error {
+					if server.linkByName("handbook") == nil {
+						return fmt.Errorf("expected the abandoned link to be kept")
+					}
+					return nil
+				},
+			},
+			// The override allows the replacement.
+			{
+				Config: providerConfig + wikiConfig("  force_destroy_heavily_used = true"),
+			},
+			{
+				Config: providerConfig + wikiConfig("  force_destroy_heavily_used = true\n  private = true"),
+				Check:  resource.TestCheckResourceAttr("golinks_link.wiki", "private", "true"),
+			},
+		},
+	})
+}
+
+func TestLinkResourceDeletionPolicyAbandon(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			server.mu.Lock()
+			defer server.mu.Unlock()
+
+			for _, link := range server.links {
+				if link.Name == "handed-back" {
+					return nil
+				}
+			}
+			return fmt.Errorf("abandoned link was deleted")
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name            = "handed-back"
+  url             = "https://google.com"
+  description     = "Link handed back to humans"
+  deletion_policy = "abandon"
+}
+`,
+				Check: resource.TestCheckResourceAttr("golinks_link.test", "deletion_policy", "abandon"),
+			},
+		},
+	})
+}
+
+func TestLinkResourceTimeouts(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "slow"
+  url         = "https://google.com"
+  description = "Link with custom timeouts"
+
+  timeouts {
+    create = "1m"
+    delete = "2m"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("golinks_link.test", "timeouts.create", "1m"),
+					resource.TestCheckResourceAttr("golinks_link.test", "timeouts.delete", "2m"),
+				),
+			},
+		},
+	})
+}
+
+func TestLinkResourceCreateTimeout(t *testing.T) {
+	server := newTestServer(t)
+	server.createDelay = 10 * time.Second
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "slow"
+  url         = "https://google.com"
+  description = "Link created on a slow day"
+
+  timeouts {
+    create = "1s"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`context deadline exceeded`),
+			},
+		},
+	})
+}
+
+func TestLinkResourceRetries(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					server.mu.Lock()
+					defer server.mu.Unlock()
+
+					server.unavailable = 3
+				},
+				Config: server.providerConfig() + `
+resource "golinks_link" "test" {
+  name        = "flaky"
+  url         = "https://google.com"
+  description = "Link created while the API recovers"
+}
+`,
+				Check: resource.TestCheckResourceAttrSet("golinks_link.test", "gid"),
+			},
+		},
+	})
+}
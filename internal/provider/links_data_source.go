@@ -198,7 +198,7 @@ func (d *linksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 func (d *linksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state golinksDataSourceModel
 
-	golinksResp, err := d.client.GetGolinks(ctx)
+	golinksResp, err := d.client.GetGolinks(ctx, client.GolinksQuery{})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read GoLinks",
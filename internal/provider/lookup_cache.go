@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
+)
+
+// lookupCacheTTL bounds how long a lookup is reused. A provider process only
+// lives for a single Terraform operation, so this mostly protects long runs
+// from acting on stale answers.
+const lookupCacheTTL = time.Minute
+
+// lookupCache remembers which link, if any, uses a name or an alias, so that
+// planning many links does not look up the same key repeatedly.
+type lookupCache struct {
+	mu      sync.Mutex
+	entries map[string]lookupCacheEntry
+}
+
+type lookupCacheEntry struct {
+	// gid is zero when no link uses the key.
+	gid     int64
+	expires time.Time
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{entries: map[string]lookupCacheEntry{}}
+}
+
+// linkUsing returns the gid of the link whose name or alias is key, or zero
+// when the key is free.
+func (c *lookupCache) linkUsing(ctx context.Context, apiClient *client.Client, key string) (int64, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.gid, nil
+	}
+
+	gid, err := lookupKey(ctx, apiClient, key)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = lookupCacheEntry{gid: gid, expires: time.Now().Add(lookupCacheTTL)}
+	c.mu.Unlock()
+
+	return gid, nil
+}
+
+func lookupKey(ctx context.Context, apiClient *client.Client, key string) (int64, error) {
+	link, err := apiClient.GetGolinksByName(ctx, key)
+	if err == nil && link.Gid != 0 {
+		return link.Gid, nil
+	}
+	if err != nil && !client.IsNotFound(err) {
+		return 0, err
+	}
+
+	link, err = apiClient.GetGolinksByAlias(ctx, key)
+	if client.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return link.Gid, nil
+}
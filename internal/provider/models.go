@@ -89,6 +89,25 @@ var UserResourceSchemaAttributes = map[string]rsschema.Attribute{
 	},
 }
 
+var RedirectHitsResourceSchemaAttributes = map[string]rsschema.Attribute{
+	"daily": rsschema.Int64Attribute{
+		Computed:    true,
+		Description: "Redirects in the last day.",
+	},
+	"weekly": rsschema.Int64Attribute{
+		Computed:    true,
+		Description: "Redirects in the last week.",
+	},
+	"monthly": rsschema.Int64Attribute{
+		Computed:    true,
+		Description: "Redirects in the last month.",
+	},
+	"alltime": rsschema.Int64Attribute{
+		Computed:    true,
+		Description: "Redirects since the golink was created.",
+	},
+}
+
 var UserDataSourceSchemaAttributes = map[string]dsschema.Attribute{
 	"uid": dsschema.Int64Attribute{
 		Computed:    true,
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &normalizeNameFunction{}
+
+// NewNormalizeNameFunction is a helper function to simplify the provider implementation.
+func NewNormalizeNameFunction() function.Function {
+	return &normalizeNameFunction{}
+}
+
+// normalizeNameFunction is the function implementation.
+type normalizeNameFunction struct{}
+
+// Metadata returns the function name.
+func (f *normalizeNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_name"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *normalizeNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize a go link name",
+		Description: "Applies the rules GoLinks uses for links with `format` enabled: punctuation is removed, and whitespace is replaced with hyphens when `hyphens` is true or removed otherwise.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The go link name to normalize.",
+			},
+			function.BoolParameter{
+				Name:        "hyphens",
+				Description: "Whether whitespace is replaced with hyphens instead of being removed.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the given name.
+func (f *normalizeNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	var hyphens bool
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &hyphens))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, NormalizeLinkName(name, hyphens)))
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeNameFunction(t *testing.T) {
+	tests := []struct {
+		name    string
+		hyphens bool
+		want    string
+	}{
+		{name: "wiki", want: "wiki"},
+		{name: "Team Wiki!", want: "TeamWiki"},
+		{name: "Team Wiki!", hyphens: true, want: "Team-Wiki"},
+		{name: "  on-call   rota ", hyphens: true, want: "on-call-rota"},
+		{name: "q3 / okrs", hyphens: true, want: "q3-okrs"},
+		{name: "snake_case.v2", want: "snake_casev2"},
+		{name: "!?", want: ""},
+	}
+
+	for _, test := range tests {
+		got, err := runFunction(t, NewNormalizeNameFunction(), types.StringUnknown(),
+			types.StringValue(test.name), types.BoolValue(test.hyphens))
+		if err != nil {
+			t.Fatalf("normalize_name(%q, %t): %s", test.name, test.hyphens, err)
+		}
+		if want := types.StringValue(test.want); !got.Equal(want) {
+			t.Errorf("normalize_name(%q, %t) = %s, want %s", test.name, test.hyphens, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parsedGoURLAttrTypes are the attribute types of the parse_go_url result.
+var parsedGoURLAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"path": types.ListType{ElemType: types.StringType},
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseGoURLFunction{}
+
+// NewParseGoURLFunction is a helper function to simplify the provider implementation.
+func NewParseGoURLFunction() function.Function {
+	return &parseGoURLFunction{}
+}
+
+// parseGoURLFunction is the function implementation.
+type parseGoURLFunction struct{}
+
+// parsedGoURLModel maps the parse_go_url result.
+type parsedGoURLModel struct {
+	Name types.String `tfsdk:"name"`
+	Path []string     `tfsdk:"path"`
+}
+
+// Metadata returns the function name.
+func (f *parseGoURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_go_url"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseGoURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse a go link URL",
+		Description: "Splits a go link such as `go/wiki/page`, `https://go/wiki/page` or `https://golinks.io/wiki/page` into an object with the link `name` and the `path` segments that follow it.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "The go link URL to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedGoURLAttrTypes,
+		},
+	}
+}
+
+// Run parses the go link URL.
+func (f *parseGoURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var url string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &url))
+	if resp.Error != nil {
+		return
+	}
+
+	name, segments, ok := ParseGoLinkURL(url)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q is not a go link URL. Expected a URL such as go/name or https://go/name.", url))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parsedGoURLModel{
+		Name: types.StringValue(name),
+		Path: segments,
+	}))
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseGoURLFunction(t *testing.T) {
+	tests := []struct {
+		url  string
+		name string
+		path []string
+	}{
+		{url: "go/wiki", name: "wiki", path: []string{}},
+		{url: "https://go/wiki/", name: "wiki", path: []string{}},
+		{url: "http://go/jira/ENG/123", name: "jira", path: []string{"ENG", "123"}},
+		{url: "https://golinks.io/docs/api?tab=1#intro", name: "docs", path: []string{"api"}},
+	}
+
+	for _, test := range tests {
+		got, err := runFunction(t, NewParseGoURLFunction(), types.ObjectUnknown(parsedGoURLAttrTypes),
+			types.StringValue(test.url))
+		if err != nil {
+			t.Fatalf("parse_go_url(%q): %s", test.url, err)
+		}
+
+		path := make([]attr.Value, 0, len(test.path))
+		for _, segment := range test.path {
+			path = append(path, types.StringValue(segment))
+		}
+		want := types.ObjectValueMust(parsedGoURLAttrTypes, map[string]attr.Value{
+			"name": types.StringValue(test.name),
+			"path": types.ListValueMust(types.StringType, path),
+		})
+		if !got.Equal(want) {
+			t.Errorf("parse_go_url(%q) = %s, want %s", test.url, got, want)
+		}
+	}
+
+	for _, url := range []string{"", "https://example.com/wiki", "go/"} {
+		if _, err := runFunction(t, NewParseGoURLFunction(), types.ObjectUnknown(parsedGoURLAttrTypes),
+			types.StringValue(url)); err == nil {
+			t.Errorf("parse_go_url(%q): expected an error", url)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &pinAction{}
+	_ action.ActionWithConfigure = &pinAction{}
+)
+
+// NewPinAction is a helper function to simplify the provider implementation.
+func NewPinAction() action.Action {
+	return &pinAction{}
+}
+
+// pinAction is the action implementation.
+type pinAction struct {
+	client   *client.Client
+	settings providerSettings
+}
+
+// pinActionModel maps the action schema data.
+type pinActionModel struct {
+	Gid               types.Int64  `tfsdk:"gid"`
+	Name              types.String `tfsdk:"name"`
+	Duration          types.String `tfsdk:"duration"`
+	OverrideOwnership types.Bool   `tfsdk:"override_ownership"`
+}
+
+// Metadata returns the action type name.
+func (a *pinAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pin"
+}
+
+// Schema defines the schema for the action.
+func (a *pinAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Pins a GoLink to the top of the company's link list, optionally for a limited time.",
+		Attributes: map[string]schema.Attribute{
+			"gid": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The ID of the GoLink to pin. Exactly one of `gid` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the GoLink to pin, without the provider `name_prefix`. Exactly one of `gid` or `name` must be set.",
+			},
+			"duration": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long the link stays pinned, as a duration such as `168h`. The link stays pinned until unpinned when unset.",
+			},
+			"override_ownership": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the link may be pinned even when its ownership marker names another Terraform workspace than the provider's `ownership_marker`.",
+			},
+		},
+	}
+}
+
+// Invoke pins the link.
+func (a *pinAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config pinActionModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Gid.IsNull() == config.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid GoLink Reference",
+			"Exactly one of `gid` or `name` must be set to identify the GoLink to pin.",
+		)
+		return
+	}
+
+	var expiresAt int64
+	if !config.Duration.IsNull() {
+		duration, err := time.ParseDuration(config.Duration.ValueString())
+		if err != nil || duration <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("duration"),
+				"Invalid Pin Duration",
+				fmt.Sprintf("The duration must be a positive Go duration such as \"168h\", got %q.", config.Duration.ValueString()),
+			)
+			return
+		}
+		expiresAt = time.Now().Add(duration).Unix()
+	}
+
+	golink := lookupActionLink(ctx, a.client, a.settings, config.Gid, config.Name, config.OverrideOwnership.ValueBool(), "pin", &resp.Diagnostics)
+	if golink == nil {
+		return
+	}
+
+	if _, err := a.client.PinLink(ctx, golink.Gid, expiresAt); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Pin GoLink",
+			fmt.Sprintf("Could not pin link %q: %s", golink.Name, err.Error()),
+		)
+		return
+	}
+
+	message := fmt.Sprintf("Pinned go/%s", golink.Name)
+	if expiresAt != 0 {
+		message += " until " + time.Unix(expiresAt, 0).UTC().Format(time.RFC3339)
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: message})
+}
+
+// Configure adds the provider configured client to the action.
+func (a *pinAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = data.Client
+	a.settings = data.Settings
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPinAction(t *testing.T) {
+	server := newTestServer(t)
+	wiki := server.addLink(client.GolinkResponse{Name: "wiki", URL: "https://wiki.example.com"})
+	docs := server.addLink(client.GolinkResponse{Name: "docs", URL: "https://docs.example.com"})
+	a := &pinAction{client: server.client(t)}
+
+	resp, progress := invokeAction(t, a, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "wiki"),
+		"duration": tftypes.NewValue(tftypes.String, "168h"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(progress) != 1 {
+		t.Errorf("expected one progress message, got %v", progress)
+	}
+
+	expiresAt := time.Unix(server.pins[wiki.Gid], 0)
+	if until := time.Until(expiresAt); until < 167*time.Hour || until > 168*time.Hour {
+		t.Errorf("pin of %q expires at %s, want in a week", wiki.Name, expiresAt)
+	}
+
+	resp, _ = invokeAction(t, a, map[string]tftypes.Value{
+		"gid": tftypes.NewValue(tftypes.Number, docs.Gid),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if expiresAt, ok := server.pins[docs.Gid]; !ok || expiresAt != 0 {
+		t.Errorf("expected %q to be pinned indefinitely, got expiry %d", docs.Name, expiresAt)
+	}
+
+	for _, attrs := range []map[string]tftypes.Value{
+		{},
+		{"name": tftypes.NewValue(tftypes.String, "wiki"), "duration": tftypes.NewValue(tftypes.String, "a week")},
+		{"name": tftypes.NewValue(tftypes.String, "missing")},
+	} {
+		if resp, _ := invokeAction(t, a, attrs); !resp.Diagnostics.HasError() {
+			t.Errorf("expected an error for configuration %v", attrs)
+		}
+	}
+}
+
+func TestPinActionNamespace(t *testing.T) {
+	server := newTestServer(t)
+	payments := server.addLink(client.GolinkResponse{Name: "payments/wiki", URL: "https://wiki.example.com"})
+	sales := server.addLink(client.GolinkResponse{Name: "sales/wiki", URL: "https://wiki.example.com"})
+	a := &pinAction{client: server.client(t), settings: providerSettings{NamePrefix: "payments/"}}
+
+	for _, attrs := range []map[string]tftypes.Value{
+		{"gid": tftypes.NewValue(tftypes.Number, sales.Gid)},
+		{"name": tftypes.NewValue(tftypes.String, "sales/wiki")},
+	} {
+		if resp, _ := invokeAction(t, a, attrs); !resp.Diagnostics.HasError() {
+			t.Errorf("expected an error for configuration %v", attrs)
+		}
+	}
+	if _, ok := server.pins[sales.Gid]; ok {
+		t.Errorf("pinned %q outside the name prefix", sales.Name)
+	}
+
+	resp, _ := invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "wiki"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if _, ok := server.pins[payments.Gid]; !ok {
+		t.Errorf("expected %q to be pinned", payments.Name)
+	}
+}
+
+func TestPinActionOwnership(t *testing.T) {
+	server := newTestServer(t)
+	link := server.addLink(client.GolinkResponse{
+		Name: "wiki",
+		URL:  "https://wiki.example.com",
+		Tags: []client.TagResponse{{Tid: 1, Name: "managed-by:sales"}},
+	})
+	a := &pinAction{client: server.client(t), settings: providerSettings{
+		Ownership: linkOwnership{Workspace: "payments", Marker: ownershipMarkerTag},
+	}}
+
+	resp, _ := invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "wiki"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a link owned by another workspace")
+	}
+	if _, ok := server.pins[link.Gid]; ok {
+		t.Errorf("pinned %q owned by another workspace", link.Name)
+	}
+
+	resp, _ = invokeAction(t, a, map[string]tftypes.Value{
+		"name":               tftypes.NewValue(tftypes.String, "wiki"),
+		"override_ownership": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if _, ok := server.pins[link.Gid]; !ok {
+		t.Errorf("expected %q to be pinned", link.Name)
+	}
+}
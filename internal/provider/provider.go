@@ -5,11 +5,17 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"terraform-provider-golinks/internal/client"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -20,12 +26,167 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &golinksProvider{}
+	_ provider.Provider                  = &golinksProvider{}
+	_ provider.ProviderWithListResources = &golinksProvider{}
+	_ provider.ProviderWithFunctions     = &golinksProvider{}
+	_ provider.ProviderWithActions       = &golinksProvider{}
 )
 
 // golinksProviderModel maps provider schema data to a Go type.
 type golinksProviderModel struct {
-	Token types.String `tfsdk:"token"`
+	Host                    types.String `tfsdk:"host"`
+	Token                   types.String `tfsdk:"token"`
+	DestroyGuardMonthlyHits types.Int64  `tfsdk:"destroy_guard_monthly_hits"`
+	AdoptExistingLinks      types.Bool   `tfsdk:"adopt_existing_links"`
+	DeletionProtection      types.Bool   `tfsdk:"deletion_protection"`
+	NamePrefix              types.String `tfsdk:"name_prefix"`
+	AllowedNamePrefixes     types.Set    `tfsdk:"allowed_name_prefixes"`
+
+	SkipCredentialsValidation types.Bool `tfsdk:"skip_credentials_validation"`
+	ReadOnly                  types.Bool `tfsdk:"read_only"`
+
+	Defaults   *golinksDefaultsModel   `tfsdk:"defaults"`
+	IgnoreTags *golinksIgnoreTagsModel `tfsdk:"ignore_tags"`
+
+	OwnershipMarker *golinksOwnershipMarkerModel `tfsdk:"ownership_marker"`
+}
+
+// golinksOwnershipMarkerModel maps the provider ownership_marker block.
+type golinksOwnershipMarkerModel struct {
+	Workspace types.String `tfsdk:"workspace"`
+	Marker    types.String `tfsdk:"marker"`
+}
+
+// golinksIgnoreTagsModel maps the provider ignore_tags block.
+type golinksIgnoreTagsModel struct {
+	Names        types.Set `tfsdk:"names"`
+	NamePrefixes types.Set `tfsdk:"name_prefixes"`
+}
+
+// golinksDefaultsModel maps the provider defaults block.
+type golinksDefaultsModel struct {
+	Tags              types.Set    `tfsdk:"tags"`
+	Unlisted          types.Bool   `tfsdk:"unlisted"`
+	Public            types.Bool   `tfsdk:"public"`
+	DescriptionSuffix types.String `tfsdk:"description_suffix"`
+}
+
+// providerData is handed to resources during Configure. It carries the API
+// client together with the provider-level settings resources must honor.
+type providerData struct {
+	Client   *client.Client
+	Settings providerSettings
+
+	// Lookups caches name and alias lookups for the provider process.
+	Lookups *lookupCache
+}
+
+// providerSettings holds the provider-level resource behavior settings.
+type providerSettings struct {
+	// ReadOnly forbids plans that create, update or destroy links.
+	ReadOnly bool
+
+	// DestroyGuardMonthlyHits is the monthly redirect hits above which a
+	// link may not be destroyed or replaced. Zero disables the guard.
+	DestroyGuardMonthlyHits int64
+
+	// AdoptExistingLinks is the default of the adopt_existing resource
+	// attribute.
+	AdoptExistingLinks bool
+
+	// DeletionProtection is the default of the deletion_protection resource
+	// attribute.
+	DeletionProtection bool
+
+	// Defaults are merged into the plan of every link.
+	Defaults linkDefaults
+
+	// IgnoreTags are the tags managed outside of Terraform.
+	IgnoreTags ignoreTags
+
+	// Ownership stamps links with the workspace managing them.
+	Ownership linkOwnership
+
+	// NamePrefix is prepended to the name of every link and stripped when
+	// reading it back.
+	NamePrefix string
+
+	// AllowedNamePrefixes restricts the names and aliases the provider may
+	// manage. Every name is allowed when empty.
+	AllowedNamePrefixes []string
+}
+
+// qualifiedName returns the name the API knows a link or alias by, which is
+// the configured name after the provider name prefix. The prefix is prepended
+// even when the configured name already starts with it, so that every name
+// maps to a single API name.
+func (s providerSettings) qualifiedName(name string) string {
+	return s.NamePrefix + name
+}
+
+// unqualifiedName returns the configured name of the link or alias the API
+// knows as name. It is the inverse of qualifiedName.
+func (s providerSettings) unqualifiedName(name string) string {
+	return strings.TrimPrefix(name, s.NamePrefix)
+}
+
+// allowsName reports whether the provider may manage a link or alias named
+// name, which includes any name prefix.
+func (s providerSettings) allowsName(name string) bool {
+	if len(s.AllowedNamePrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range s.AllowedNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownsName reports whether a link named name belongs to the namespace of the
+// provider: it carries the name prefix and is allowed.
+func (s providerSettings) ownsName(name string) bool {
+	return strings.HasPrefix(name, s.NamePrefix) && s.allowsName(name)
+}
+
+// ignoreTags matches the tags the provider neither reads nor removes.
+type ignoreTags struct {
+	Names    []string
+	Prefixes []string
+}
+
+// enabled reports whether any tag is ignored.
+func (i ignoreTags) enabled() bool {
+	return len(i.Names) > 0 || len(i.Prefixes) > 0
+}
+
+// matches reports whether tag must be ignored.
+func (i ignoreTags) matches(tag string) bool {
+	if slices.Contains(i.Names, tag) {
+		return true
+	}
+	for _, prefix := range i.Prefixes {
+		if strings.HasPrefix(tag, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkDefaults holds the values of the provider defaults block.
+type linkDefaults struct {
+	// Tags are added to the tags of every link.
+	Tags []string
+
+	// Unlisted and Public are used when a link does not configure them.
+	// They are null when the provider does not configure them either.
+	Unlisted types.Bool
+	Public   types.Bool
+
+	// DescriptionSuffix is appended to the description of every link, with
+	// "{name}" replaced by the link name.
+	DescriptionSuffix string
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -55,11 +216,96 @@ func (p *golinksProvider) Metadata(_ context.Context, _ provider.MetadataRequest
 func (p *golinksProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				Description: "URL of the GoLinks API. Defaults to https://api.golinks.io. May also be set with the GOLINKS_HOST environment variable.",
+				Optional:    true,
+			},
 			"token": schema.StringAttribute{
 				Description: "API Token for authenticating with the GoLinks API.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "If true, the token is never validated against the GoLinks API. Otherwise it is validated when a resource, data source or action first calls the API, rather than when the provider is configured. Defaults to false.",
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "If true, planning the creation, update or destruction of a `golinks_link` fails, and the provider refuses every API request that could change links. Data sources keep working. Defaults to false.",
+				Optional:    true,
+			},
+			"destroy_guard_monthly_hits": schema.Int64Attribute{
+				Description: "When set, planning the destruction or replacement of a golink whose monthly redirect hits exceed this value fails, unless the link has `force_destroy_heavily_used` set.",
+				Optional:    true,
+			},
+			"adopt_existing_links": schema.BoolAttribute{
+				Description: "Default for the `adopt_existing` attribute of `golinks_link`. Defaults to false.",
+				Optional:    true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Default for the `deletion_protection` attribute of `golinks_link`. Defaults to false.",
+				Optional:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Prefix prepended to the name and aliases of every `golinks_link`, such as `payments/`, and stripped when reading it back. Imports, list blocks and actions only consider links carrying the prefix.",
+				Optional:    true,
+			},
+			"allowed_name_prefixes": schema.SetAttribute{
+				Description: "When set, planning a `golinks_link` whose name or aliases do not start with one of these prefixes fails, and imports, list blocks and actions ignore such links.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"defaults": schema.SingleNestedBlock{
+				Description: "Values merged into every `golinks_link`. The resulting values are exposed in the `tags_all` and `description_all` attributes of the resource.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.SetAttribute{
+						Description: "Tags added to every link.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"unlisted": schema.BoolAttribute{
+						Description: "Default for the `unlisted` attribute of `golinks_link`.",
+						Optional:    true,
+					},
+					"public": schema.BoolAttribute{
+						Description: "Default for the `public` attribute of `golinks_link`.",
+						Optional:    true,
+					},
+					"description_suffix": schema.StringAttribute{
+						Description: "Text appended to the description of every link. `{name}` is replaced by the link name.",
+						Optional:    true,
+					},
+				},
+			},
+			"ownership_marker": schema.SingleNestedBlock{
+				Description: "Stamps every link written by the provider with the identifier of the Terraform workspace. Links stamped by another workspace are not updated, adopted or deleted unless their `override_ownership` is set.",
+				Attributes: map[string]schema.Attribute{
+					"workspace": schema.StringAttribute{
+						Description: "Identifier of the Terraform workspace managing the links. Required when the block is set.",
+						Optional:    true,
+					},
+					"marker": schema.StringAttribute{
+						Description: "Where the workspace is stamped: `tag` adds a reserved `managed-by:<workspace>` tag, `description` appends a footer to the description. Defaults to `tag`.",
+						Optional:    true,
+					},
+				},
+			},
+			"ignore_tags": schema.SingleNestedBlock{
+				Description: "Tags managed outside of Terraform, for example by GoLinks admins in the UI. Matching tags are neither read into `golinks_link` nor removed from links.",
+				Attributes: map[string]schema.Attribute{
+					"names": schema.SetAttribute{
+						Description: "Exact tag names to ignore.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"name_prefixes": schema.SetAttribute{
+						Description: "Prefixes of the tag names to ignore.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
 		},
 	}
 }
@@ -77,9 +323,30 @@ func (p *golinksProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	// When the configuration depends on values that are not known yet, such
+	// as a token read by another resource, and Terraform supports deferred
+	// actions, the resources and data sources of the provider are deferred
+	// to a later plan instead of failing.
+	if req.ClientCapabilities.DeferralAllowed && !req.Config.Raw.IsFullyKnown() {
+		tflog.Info(ctx, "Deferring GoLinks client configuration as the provider configuration is unknown")
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
+		return
+	}
+
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 
+	if config.Host.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Unknown GoLinks API Host",
+			"The provider cannot create the GoLinks API client as there is an unknown configuration value for the GoLinks API host. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the GOLINKS_HOST environment variable.",
+		)
+	}
+
 	if config.Token.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
@@ -89,6 +356,127 @@ func (p *golinksProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 	}
 
+	if config.SkipCredentialsValidation.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("skip_credentials_validation"),
+			"Unknown Credentials Validation Setting",
+			"The provider cannot create the GoLinks API client as there is an unknown configuration value for `skip_credentials_validation`. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown Read-Only Setting",
+			"The provider cannot decide whether links may be changed as there is an unknown configuration value for `read_only`. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DestroyGuardMonthlyHits.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("destroy_guard_monthly_hits"),
+			"Unknown Destroy Guard Threshold",
+			"The provider cannot guard link destruction as there is an unknown configuration value for the monthly redirect hits threshold. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if !config.DestroyGuardMonthlyHits.IsNull() && config.DestroyGuardMonthlyHits.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("destroy_guard_monthly_hits"),
+			"Invalid Destroy Guard Threshold",
+			"The monthly redirect hits threshold must not be negative.",
+		)
+	}
+
+	var defaults linkDefaults
+	if config.Defaults != nil {
+		d := config.Defaults
+		if d.Tags.IsUnknown() || d.Unlisted.IsUnknown() || d.Public.IsUnknown() || d.DescriptionSuffix.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("defaults"),
+				"Unknown Link Defaults",
+				"The provider cannot apply link defaults as there is an unknown configuration value in the defaults block. "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+			return
+		}
+
+		diags = d.Tags.ElementsAs(ctx, &defaults.Tags, true)
+		resp.Diagnostics.Append(diags...)
+		defaults.Unlisted = d.Unlisted
+		defaults.Public = d.Public
+		defaults.DescriptionSuffix = d.DescriptionSuffix.ValueString()
+	}
+
+	if config.NamePrefix.IsUnknown() || config.AllowedNamePrefixes.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Name Prefixes",
+			"The provider cannot enforce link name prefixes as there is an unknown configuration value for `name_prefix` or `allowed_name_prefixes`. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return
+	}
+
+	var allowedNamePrefixes []string
+	diags = config.AllowedNamePrefixes.ElementsAs(ctx, &allowedNamePrefixes, true)
+	resp.Diagnostics.Append(diags...)
+
+	var ownership linkOwnership
+	if config.OwnershipMarker != nil {
+		m := config.OwnershipMarker
+		if m.Workspace.IsUnknown() || m.Marker.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ownership_marker"),
+				"Unknown Ownership Marker",
+				"The provider cannot stamp links as there is an unknown configuration value in the ownership_marker block. "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+			return
+		}
+
+		ownership.Workspace = m.Workspace.ValueString()
+		ownership.Marker = ownershipMarkerTag
+		if !m.Marker.IsNull() {
+			ownership.Marker = m.Marker.ValueString()
+		}
+
+		if ownership.Workspace == "" || strings.Contains(ownership.Workspace, "\n") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ownership_marker").AtName("workspace"),
+				"Invalid Ownership Workspace",
+				"The workspace identifier must be a non-empty, single-line string.",
+			)
+		}
+		if ownership.Marker != ownershipMarkerTag && ownership.Marker != ownershipMarkerDescription {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ownership_marker").AtName("marker"),
+				"Invalid Ownership Marker",
+				fmt.Sprintf("The marker must be %q or %q, got: %q.", ownershipMarkerTag, ownershipMarkerDescription, ownership.Marker),
+			)
+		}
+	}
+
+	var ignore ignoreTags
+	if config.IgnoreTags != nil {
+		if config.IgnoreTags.Names.IsUnknown() || config.IgnoreTags.NamePrefixes.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ignore_tags"),
+				"Unknown Ignored Tags",
+				"The provider cannot ignore tags as there is an unknown configuration value in the ignore_tags block. "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+			return
+		}
+
+		diags = config.IgnoreTags.Names.ElementsAs(ctx, &ignore.Names, true)
+		resp.Diagnostics.Append(diags...)
+		diags = config.IgnoreTags.NamePrefixes.ElementsAs(ctx, &ignore.Prefixes, true)
+		resp.Diagnostics.Append(diags...)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -96,8 +484,13 @@ func (p *golinksProvider) Configure(ctx context.Context, req provider.ConfigureR
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
+	host := os.Getenv("GOLINKS_HOST")
 	token := os.Getenv("GOLINKS_TOKEN")
 
+	if !config.Host.IsNull() {
+		host = config.Host.ValueString()
+	}
+
 	if !config.Token.IsNull() {
 		token = config.Token.ValueString()
 	}
@@ -119,12 +512,15 @@ func (p *golinksProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	ctx = tflog.SetField(ctx, "golinks_host", host)
 	ctx = tflog.SetField(ctx, "golinks_token", token)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "golinks_token")
 	tflog.Debug(ctx, "Creating GoLinks client")
 
-	// Create a new GoLinks client using the configuration values
-	client, err := client.NewClient(ctx, &token)
+	// Create a new GoLinks client using the configuration values. The client
+	// only contacts the API once a resource or data source needs it, so that
+	// plans without API calls succeed when the API is unreachable.
+	client, err := client.NewLazyClient(&host, &token, config.SkipCredentialsValidation.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create GoLinks API Client",
@@ -134,11 +530,29 @@ func (p *golinksProvider) Configure(ctx context.Context, req provider.ConfigureR
 		)
 		return
 	}
+	client.ReadOnly = config.ReadOnly.ValueBool()
 
 	// Make the GoLinks client available during DataSource and Resource
 	// type Configure methods.
+	data := &providerData{
+		Client: client,
+		Settings: providerSettings{
+			ReadOnly:                config.ReadOnly.ValueBool(),
+			DestroyGuardMonthlyHits: config.DestroyGuardMonthlyHits.ValueInt64(),
+			AdoptExistingLinks:      config.AdoptExistingLinks.ValueBool(),
+			DeletionProtection:      config.DeletionProtection.ValueBool(),
+			Defaults:                defaults,
+			IgnoreTags:              ignore,
+			Ownership:               ownership,
+			NamePrefix:              config.NamePrefix.ValueString(),
+			AllowedNamePrefixes:     allowedNamePrefixes,
+		},
+		Lookups: newLookupCache(),
+	}
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = data
+	resp.ListResourceData = data
+	resp.ActionData = data
 
 	tflog.Info(ctx, "Configured GoLinks client", map[string]any{"success": true})
 }
@@ -148,6 +562,8 @@ func (p *golinksProvider) DataSources(_ context.Context) []func() datasource.Dat
 	return []func() datasource.DataSource{
 		LinksDataSource,
 		LinkDataSource,
+		LinkAnalyticsDataSource,
+		UsageReportDataSource,
 	}
 }
 
@@ -157,3 +573,29 @@ func (p *golinksProvider) Resources(_ context.Context) []func() resource.Resourc
 		NewLinkResource,
 	}
 }
+
+// ListResources defines the list resources implemented in the provider.
+func (p *golinksProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewLinkListResource,
+	}
+}
+
+// Actions defines the actions implemented in the provider.
+func (p *golinksProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewPinAction,
+		NewVerifyDestinationAction,
+		NewRollbackURLAction,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *golinksProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewNormalizeNameFunction,
+		NewGoURLFunction,
+		NewParseGoURLFunction,
+		NewExpandVariableLinkFunction,
+	}
+}
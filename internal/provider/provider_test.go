@@ -4,12 +4,26 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func testAccProviderConfig(t *testing.T) string {
@@ -36,3 +50,255 @@ var (
 		"golinks": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+func TestProviderSkipCredentialsValidation(t *testing.T) {
+	server := newTestServer(t)
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Provider-defined functions.
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				// Configuring the provider must not contact the API.
+				Config: fmt.Sprintf(`
+provider "golinks" {
+  host  = %q
+  token = %q
+}
+
+output "name" {
+  value = provider::golinks::normalize_name("Team Wiki")
+}
+`, unreachable.URL, testServerToken),
+				Check: resource.TestCheckOutput("name", "TeamWiki"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "golinks" {
+  host                        = %q
+  token                       = %q
+  skip_credentials_validation = true
+}
+
+resource "golinks_link" "wiki" {
+  name        = "wiki"
+  url         = "https://wiki.example.com"
+  description = "Team wiki"
+}
+`, server.URL, testServerToken),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("golinks_link.wiki", "gid"),
+					func(*terraform.State) error {
+						server.mu.Lock()
+						defer server.mu.Unlock()
+
+						if server.signIns != 0 {
+							return fmt.Errorf("expected no credentials validation, got %d", server.signIns)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestProviderConfigureDeferred(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	// The token comes from a resource that has not been applied yet.
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["token"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+
+	tests := []struct {
+		name            string
+		deferralAllowed bool
+		wantDeferred    bool
+	}{
+		{name: "deferral allowed", deferralAllowed: true, wantDeferred: true},
+		{name: "deferral not allowed"},
+	}
+	for _, test := range tests {
+		req := provider.ConfigureRequest{
+			Config:             config,
+			ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: test.deferralAllowed},
+		}
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, req, &resp)
+
+		if deferred := resp.Deferred != nil; deferred != test.wantDeferred {
+			t.Errorf("%s: deferred = %t, want %t", test.name, deferred, test.wantDeferred)
+		}
+		if resp.Diagnostics.HasError() == test.wantDeferred {
+			t.Errorf("%s: unexpected diagnostics: %v", test.name, resp.Diagnostics)
+		}
+		if resp.Deferred != nil && resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+			t.Errorf("%s: deferred reason = %s", test.name, resp.Deferred.Reason)
+		}
+	}
+}
+
+func TestProviderLazySignIn(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+	server.addLink(client.GolinkResponse{Name: "wiki", URL: "https://wiki.example.com"})
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["host"] = tftypes.NewValue(tftypes.String, server.URL)
+	values["token"] = tftypes.NewValue(tftypes.String, testServerToken)
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, values),
+		},
+	}
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	c := resp.DataSourceData.(*client.Client)
+
+	signIns := func() int {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+
+		return server.signIns
+	}
+	if n := signIns(); n != 0 {
+		t.Fatalf("Configure signed in %d times, want no sign-in", n)
+	}
+
+	// The sign-in is retried after a transient failure, and does not
+	// inherit the deadline of the request.
+	server.mu.Lock()
+	server.unavailable = 1
+	server.mu.Unlock()
+	expired, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := c.GetGolinksByName(expired, "wiki"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetGolinksByName() error = %v, want %v", err, context.Canceled)
+	}
+	if n := signIns(); n != 1 {
+		t.Fatalf("signed in %d times, want 1", n)
+	}
+
+	if _, err := c.GetGolinksByName(ctx, "wiki"); err != nil {
+		t.Fatal(err)
+	}
+	if n := signIns(); n != 1 {
+		t.Fatalf("signed in %d times after the first request, want 1", n)
+	}
+
+	// A sign-in that failed for good is not remembered. Like the create it
+	// precedes, it is not retried after a 503.
+	host, token := server.URL, testServerToken
+	lazy, err := client.NewLazyClient(&host, &token, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.mu.Lock()
+	server.unavailable = 1
+	server.mu.Unlock()
+	_, err = lazy.CreateLink(ctx, client.CreateLinkRequest{Name: "docs", URL: "https://docs.example.com"})
+	if err == nil || !strings.Contains(err.Error(), "unable to validate credentials") {
+		t.Fatalf("CreateLink() error = %v, want a sign-in error", err)
+	}
+	if _, err := lazy.GetGolinksByName(ctx, "wiki"); err != nil {
+		t.Fatalf("expected the sign-in to be retried, got: %v", err)
+	}
+	if n := signIns(); n != 2 {
+		t.Fatalf("signed in %d times, want 2", n)
+	}
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	server := newTestServer(t)
+	link := server.addLink(client.GolinkResponse{Name: "wiki", URL: "https://wiki.example.com"})
+
+	c := server.client(t)
+	c.ReadOnly = true
+
+	if _, err := c.GetGolinksByName(context.Background(), "wiki"); err != nil {
+		t.Fatalf("read-only client must read links, got: %v", err)
+	}
+	if err := c.DeleteLink(context.Background(), link.Gid); !errors.Is(err, client.ErrReadOnly) {
+		t.Fatalf("DeleteLink() error = %v, want %v", err, client.ErrReadOnly)
+	}
+	if server.linkByName("wiki") == nil {
+		t.Fatal("read-only client deleted the link")
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	server := newTestServer(t)
+	server.addLink(client.GolinkResponse{Name: "wiki", URL: "https://wiki.example.com"})
+	c := server.client(t)
+
+	server.mu.Lock()
+	server.unavailable = 2
+	server.mu.Unlock()
+
+	if _, err := c.GetGolinksByName(context.Background(), "wiki"); err != nil {
+		t.Fatalf("expected the request to be retried, got: %v", err)
+	}
+
+	// A create is not retried after a failure that may have created the link.
+	server.mu.Lock()
+	server.unavailable = 1
+	server.mu.Unlock()
+
+	_, err := c.CreateLink(context.Background(), client.CreateLinkRequest{Name: "docs", URL: "https://docs.example.com"})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("CreateLink() error = %v, want a 503 error", err)
+	}
+}
+
+func TestProviderSettingsQualifiedName(t *testing.T) {
+	settings := providerSettings{NamePrefix: "pay"}
+	model := linkResourceModel{
+		Name:    types.StringValue("payroll"),
+		Format:  types.BoolValue(false),
+		Hyphens: types.BoolValue(false),
+	}
+
+	// Link names and aliases get the prefix even when they already start
+	// with it, and reading them back strips exactly that prefix.
+	apiName := linkNameForAPI(model, settings)
+	if apiName != "paypayroll" || settings.qualifiedName("payroll") != apiName {
+		t.Fatalf("link name = %q, alias = %q, want both %q", apiName, settings.qualifiedName("payroll"), "paypayroll")
+	}
+	if name := NameFromResponse(types.StringNull(), model.Format, model.Hyphens, apiName, settings); name.ValueString() != "payroll" {
+		t.Errorf("NameFromResponse() = %q, want %q", name.ValueString(), "payroll")
+	}
+	if name := settings.unqualifiedName(apiName); name != "payroll" {
+		t.Errorf("unqualifiedName() = %q, want %q", name, "payroll")
+	}
+}
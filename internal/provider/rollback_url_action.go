@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &rollbackURLAction{}
+	_ action.ActionWithConfigure = &rollbackURLAction{}
+)
+
+// NewRollbackURLAction is a helper function to simplify the provider implementation.
+func NewRollbackURLAction() action.Action {
+	return &rollbackURLAction{}
+}
+
+// rollbackURLAction is the action implementation.
+type rollbackURLAction struct {
+	client   *client.Client
+	settings providerSettings
+}
+
+// rollbackURLActionModel maps the action schema data.
+type rollbackURLActionModel struct {
+	Gid               types.Int64  `tfsdk:"gid"`
+	Name              types.String `tfsdk:"name"`
+	OverrideOwnership types.Bool   `tfsdk:"override_ownership"`
+}
+
+// Metadata returns the action type name.
+func (a *rollbackURLAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rollback_url"
+}
+
+// Schema defines the schema for the action.
+func (a *rollbackURLAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resets the destination URL of a GoLink to the URL it pointed to before its latest change. " +
+			"When the link is managed by a `golinks_link` resource, the next plan shows the difference to the configured URL.",
+		Attributes: map[string]schema.Attribute{
+			"gid": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The ID of the GoLink to roll back. Exactly one of `gid` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the GoLink to roll back, without the provider `name_prefix`. Exactly one of `gid` or `name` must be set.",
+			},
+			"override_ownership": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the link may be rolled back even when its ownership marker names another Terraform workspace than the provider's `ownership_marker`.",
+			},
+		},
+	}
+}
+
+// Invoke restores the previous URL of the link.
+func (a *rollbackURLAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config rollbackURLActionModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Gid.IsNull() == config.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid GoLink Reference",
+			"Exactly one of `gid` or `name` must be set to identify the GoLink to roll back.",
+		)
+		return
+	}
+
+	golink := lookupActionLink(ctx, a.client, a.settings, config.Gid, config.Name, config.OverrideOwnership.ValueBool(), "roll back", &resp.Diagnostics)
+	if golink == nil {
+		return
+	}
+
+	history, err := a.client.GetLinkHistory(ctx, golink.Gid)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read GoLink History",
+			fmt.Sprintf("Could not get the history of link %q: %s", golink.Name, err.Error()),
+		)
+		return
+	}
+
+	// Revisions are ordered most recent first; the first one with another
+	// URL is the value before the latest URL change.
+	previousURL := ""
+	for _, revision := range history.Results {
+		if revision.URL != golink.URL {
+			previousURL = revision.URL
+			break
+		}
+	}
+	if previousURL == "" {
+		resp.Diagnostics.AddError(
+			"No Previous URL",
+			fmt.Sprintf("Link %q has always pointed to %s, so there is no URL to roll back to.", golink.Name, golink.URL),
+		)
+		return
+	}
+
+	// Updates replace every field of the link, so carry over all but the URL.
+	tags := make([]string, 0, len(golink.Tags))
+	for _, tag := range golink.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	_, err = a.client.UpdateLink(ctx, client.UpdateLinkRequest{
+		Gid:         golink.Gid,
+		URL:         previousURL,
+		Name:        golink.Name,
+		Description: golink.Description,
+		Tags:        tags,
+		Unlisted:    golink.Unlisted,
+		Private:     golink.Private,
+		Public:      golink.Public,
+		Format:      golink.Format,
+		Hyphens:     golink.Hyphens,
+		Aliases:     golink.Aliases,
+		Geolinks:    golink.Geolinks,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Roll Back GoLink",
+			fmt.Sprintf("Could not update link %q: %s", golink.Name, err.Error()),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Rolled back go/%s from %s to %s", golink.Name, golink.URL, previousURL),
+	})
+}
+
+// Configure adds the provider configured client to the action.
+func (a *rollbackURLAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = data.Client
+	a.settings = data.Settings
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRollbackURLAction(t *testing.T) {
+	server := newTestServer(t)
+	apiClient := server.client(t)
+	wiki := server.addLink(client.GolinkResponse{Name: "wiki", URL: "https://old.example.com", Description: "Team wiki"})
+	server.aliases["kb"] = wiki.Gid
+	a := &rollbackURLAction{client: apiClient}
+
+	resp, _ := invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "wiki"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a link without a previous URL")
+	}
+
+	geolinks := []client.Geolink{{Location: "DE", URL: "https://de.example.com"}}
+	if _, err := apiClient.UpdateLink(context.Background(), client.UpdateLinkRequest{
+		Gid:         wiki.Gid,
+		Name:        wiki.Name,
+		URL:         "https://new.example.com",
+		Description: wiki.Description,
+		Public:      1,
+		Aliases:     []string{"kb"},
+		Geolinks:    geolinks,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, progress := invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "wiki"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(progress) != 1 {
+		t.Errorf("expected one progress message, got %v", progress)
+	}
+
+	got := server.links[wiki.Gid]
+	if got.URL != "https://old.example.com" || got.Description != "Team wiki" {
+		t.Errorf("link after rollback = %q (%q), want https://old.example.com (Team wiki)", got.URL, got.Description)
+	}
+	if got.Public != 1 || !slices.Equal(got.Aliases, []string{"kb"}) || !slices.Equal(got.Geolinks, geolinks) {
+		t.Errorf("rollback changed more than the URL: public = %d, aliases = %v, geolinks = %v", got.Public, got.Aliases, got.Geolinks)
+	}
+}
+
+func TestRollbackURLActionNamespace(t *testing.T) {
+	server := newTestServer(t)
+	for _, name := range []string{"payments/wiki", "sales/wiki"} {
+		link := server.addLink(client.GolinkResponse{Name: name, URL: "https://new.example.com"})
+		server.history[link.Gid] = []client.LinkRevisionResponse{{URL: "https://old.example.com"}}
+	}
+	a := &rollbackURLAction{client: server.client(t), settings: providerSettings{NamePrefix: "payments/"}}
+
+	for _, attrs := range []map[string]tftypes.Value{
+		{"gid": tftypes.NewValue(tftypes.Number, server.linkByName("sales/wiki").Gid)},
+		{"name": tftypes.NewValue(tftypes.String, "sales/wiki")},
+	} {
+		if resp, _ := invokeAction(t, a, attrs); !resp.Diagnostics.HasError() {
+			t.Errorf("expected an error for configuration %v", attrs)
+		}
+	}
+	if got := server.linkByName("sales/wiki").URL; got != "https://new.example.com" {
+		t.Errorf("rolled back a link outside the name prefix to %q", got)
+	}
+
+	resp, _ := invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "wiki"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if got := server.linkByName("payments/wiki").URL; got != "https://old.example.com" {
+		t.Errorf("url after rollback = %q, want https://old.example.com", got)
+	}
+}
+
+func TestRollbackURLActionOwnership(t *testing.T) {
+	server := newTestServer(t)
+	link := server.addLink(client.GolinkResponse{
+		Name: "wiki",
+		URL:  "https://new.example.com",
+		Tags: []client.TagResponse{{Tid: 1, Name: "managed-by:sales"}},
+	})
+	server.history[link.Gid] = []client.LinkRevisionResponse{{URL: "https://old.example.com"}}
+	a := &rollbackURLAction{client: server.client(t), settings: providerSettings{
+		Ownership: linkOwnership{Workspace: "payments", Marker: ownershipMarkerTag},
+	}}
+
+	resp, _ := invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "wiki"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a link owned by another workspace")
+	}
+	if got := server.linkByName("wiki").URL; got != "https://new.example.com" {
+		t.Errorf("rolled back a link owned by another workspace to %q", got)
+	}
+
+	resp, _ = invokeAction(t, a, map[string]tftypes.Value{
+		"name":               tftypes.NewValue(tftypes.String, "wiki"),
+		"override_ownership": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if got := server.linkByName("wiki").URL; got != "https://old.example.com" {
+		t.Errorf("url after rollback = %q, want https://old.example.com", got)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultUsageReportTopN             = 10
+	defaultUsageReportMonthlyThreshold = 1
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &usageReportDataSource{}
+	_ datasource.DataSourceWithConfigure = &usageReportDataSource{}
+)
+
+// UsageReportDataSource is a helper function to simplify the provider implementation.
+func UsageReportDataSource() datasource.DataSource {
+	return &usageReportDataSource{}
+}
+
+// usageReportDataSource is the data source implementation.
+type usageReportDataSource struct {
+	client *client.Client
+}
+
+// usageReportDataSourceModel maps the data source schema data.
+type usageReportDataSourceModel struct {
+	TopN                 types.Int64            `tfsdk:"top_n"`
+	MonthlyHitsThreshold types.Int64            `tfsdk:"monthly_hits_threshold"`
+	StaleSince           types.String           `tfsdk:"stale_since"`
+	TotalLinks           types.Int64            `tfsdk:"total_links"`
+	TopLinks             []usageReportLinkModel `tfsdk:"top_links"`
+	LowUsageLinks        []usageReportLinkModel `tfsdk:"low_usage_links"`
+	StaleLinks           []usageReportLinkModel `tfsdk:"stale_links"`
+}
+
+// usageReportLinkModel maps a single link entry of the report.
+type usageReportLinkModel struct {
+	Gid          types.Int64  `tfsdk:"gid"`
+	Name         types.String `tfsdk:"name"`
+	URL          types.String `tfsdk:"url"`
+	User         types.Object `tfsdk:"user"`
+	RedirectHits types.Object `tfsdk:"redirect_hits"`
+	UpdatedAt    types.Int64  `tfsdk:"updated_at"`
+}
+
+// Metadata returns the data source type name.
+func (d *usageReportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_usage_report"
+}
+
+// usageReportLinkAttribute returns the schema shared by the report lists.
+func usageReportLinkAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:    true,
+		Description: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"gid": schema.Int64Attribute{
+					Computed:    true,
+					Description: "The GoLink ID.",
+				},
+				"name": schema.StringAttribute{
+					Computed:    true,
+					Description: "The name of the GoLink.",
+				},
+				"url": schema.StringAttribute{
+					Computed:    true,
+					Description: "The destination URL the GoLink points to.",
+				},
+				"user": schema.SingleNestedAttribute{
+					Computed:    true,
+					Description: "The user who owns the GoLink.",
+					Attributes:  UserDataSourceSchemaAttributes,
+				},
+				"redirect_hits": schema.SingleNestedAttribute{
+					Computed:    true,
+					Description: "The redirect hits statistics for the GoLink.",
+					Attributes: map[string]schema.Attribute{
+						"daily": schema.Int64Attribute{
+							Computed: true,
+						},
+						"weekly": schema.Int64Attribute{
+							Computed: true,
+						},
+						"monthly": schema.Int64Attribute{
+							Computed: true,
+						},
+						"alltime": schema.Int64Attribute{
+							Computed: true,
+						},
+					},
+				},
+				"updated_at": schema.Int64Attribute{
+					Computed:    true,
+					Description: "The timestamp when the GoLink was last updated.",
+				},
+			},
+		},
+	}
+}
+
+// Schema defines the schema for the data source.
+func (d *usageReportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the most used, least used and stale GoLinks of the company.",
+		Attributes: map[string]schema.Attribute{
+			"top_n": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of links to return in `top_links`. Defaults to 10.",
+			},
+			"monthly_hits_threshold": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Links with fewer monthly redirect hits than this value are returned in `low_usage_links`. Defaults to 1.",
+			},
+			"stale_since": schema.StringAttribute{
+				Optional:    true,
+				Description: "Links not updated since this day, formatted as YYYY-MM-DD, are returned in `stale_links`. No stale links are reported when unset.",
+			},
+			"total_links": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of links inspected.",
+			},
+			"top_links":       usageReportLinkAttribute("The most used links, ordered by monthly redirect hits, highest first."),
+			"low_usage_links": usageReportLinkAttribute("The links below `monthly_hits_threshold`, ordered by monthly redirect hits, lowest first."),
+			"stale_links":     usageReportLinkAttribute("The links not updated since `stale_since`, oldest first."),
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *usageReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state usageReportDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	topN := int64(defaultUsageReportTopN)
+	if !state.TopN.IsNull() {
+		topN = state.TopN.ValueInt64()
+	}
+	if topN < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("top_n"),
+			"Invalid Top N",
+			"The number of top links must not be negative.",
+		)
+		return
+	}
+
+	threshold := int64(defaultUsageReportMonthlyThreshold)
+	if !state.MonthlyHitsThreshold.IsNull() {
+		threshold = state.MonthlyHitsThreshold.ValueInt64()
+	}
+
+	var staleSince time.Time
+	if !state.StaleSince.IsNull() {
+		var err error
+		staleSince, err = time.Parse(analyticsDateLayout, state.StaleSince.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("stale_since"),
+				"Invalid Stale Since Date",
+				"The date must be formatted as YYYY-MM-DD: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	golinks, err := d.client.GetAllGolinks(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read GoLinks",
+			err.Error(),
+		)
+		return
+	}
+
+	byHits := make([]client.GolinkResponse, len(golinks))
+	copy(byHits, golinks)
+	sort.SliceStable(byHits, func(i, j int) bool {
+		return byHits[i].RedirectHits.Monthly > byHits[j].RedirectHits.Monthly
+	})
+
+	state.TotalLinks = types.Int64Value(int64(len(golinks)))
+
+	state.TopLinks = []usageReportLinkModel{}
+	for i := 0; i < len(byHits) && int64(i) < topN; i++ {
+		state.TopLinks = append(state.TopLinks, usageReportLinkFromResponse(byHits[i]))
+	}
+
+	state.LowUsageLinks = []usageReportLinkModel{}
+	for i := len(byHits) - 1; i >= 0; i-- {
+		if byHits[i].RedirectHits.Monthly >= threshold {
+			break
+		}
+		state.LowUsageLinks = append(state.LowUsageLinks, usageReportLinkFromResponse(byHits[i]))
+	}
+
+	state.StaleLinks = []usageReportLinkModel{}
+	if !staleSince.IsZero() {
+		byUpdate := make([]client.GolinkResponse, 0, len(golinks))
+		for _, golink := range golinks {
+			if golink.UpdatedAt < staleSince.Unix() {
+				byUpdate = append(byUpdate, golink)
+			}
+		}
+		sort.SliceStable(byUpdate, func(i, j int) bool {
+			return byUpdate[i].UpdatedAt < byUpdate[j].UpdatedAt
+		})
+		for _, golink := range byUpdate {
+			state.StaleLinks = append(state.StaleLinks, usageReportLinkFromResponse(golink))
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func usageReportLinkFromResponse(golink client.GolinkResponse) usageReportLinkModel {
+	return usageReportLinkModel{
+		Gid:          types.Int64Value(golink.Gid),
+		Name:         types.StringValue(golink.Name),
+		URL:          types.StringValue(golink.URL),
+		User:         UserToObject(golink.User),
+		RedirectHits: RedirectHitsToObject(golink.RedirectHits),
+		UpdatedAt:    types.Int64Value(golink.UpdatedAt),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *usageReportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *golinks.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
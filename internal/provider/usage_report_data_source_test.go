@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUsageReportDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(t) + `
+data "golinks_usage_report" "test" {
+  top_n                  = 5
+  monthly_hits_threshold = 10
+  stale_since            = "2020-01-01"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.golinks_usage_report.test", "total_links"),
+					resource.TestCheckResourceAttrSet("data.golinks_usage_report.test", "top_links.#"),
+					resource.TestCheckResourceAttrSet("data.golinks_usage_report.test", "low_usage_links.#"),
+					resource.TestCheckResourceAttrSet("data.golinks_usage_report.test", "stale_links.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestUsageReportDataSourceRanking(t *testing.T) {
+	server := newTestServer(t)
+	server.addLink(client.GolinkResponse{
+		Name:         "wiki",
+		URL:          "https://wiki.example.com",
+		RedirectHits: client.RedirectHitsResponse{Monthly: 50},
+		UpdatedAt:    time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	})
+	server.addLink(client.GolinkResponse{
+		Name:         "docs",
+		URL:          "https://docs.example.com",
+		RedirectHits: client.RedirectHitsResponse{Monthly: 5},
+		UpdatedAt:    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	})
+	server.addLink(client.GolinkResponse{
+		Name:         "old",
+		URL:          "https://old.example.com",
+		RedirectHits: client.RedirectHitsResponse{Monthly: 0},
+		UpdatedAt:    time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.providerConfig() + `
+data "golinks_usage_report" "test" {
+  top_n                  = 2
+  monthly_hits_threshold = 10
+  stale_since            = "2020-01-01"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "total_links", "3"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "top_links.#", "2"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "top_links.0.name", "wiki"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "top_links.0.redirect_hits.monthly", "50"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "top_links.1.name", "docs"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "top_links.1.redirect_hits.monthly", "5"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "low_usage_links.#", "2"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "low_usage_links.0.name", "old"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "low_usage_links.1.name", "docs"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "stale_links.#", "2"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "stale_links.0.name", "old"),
+					resource.TestCheckResourceAttr("data.golinks_usage_report.test", "stale_links.1.name", "wiki"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultVerifyDestinationTimeout bounds the request made to the destination.
+const defaultVerifyDestinationTimeout = 10 * time.Second
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &verifyDestinationAction{}
+	_ action.ActionWithConfigure = &verifyDestinationAction{}
+)
+
+// NewVerifyDestinationAction is a helper function to simplify the provider implementation.
+func NewVerifyDestinationAction() action.Action {
+	return &verifyDestinationAction{}
+}
+
+// verifyDestinationAction is the action implementation.
+type verifyDestinationAction struct {
+	client   *client.Client
+	settings providerSettings
+}
+
+// verifyDestinationActionModel maps the action schema data.
+type verifyDestinationActionModel struct {
+	Gid               types.Int64  `tfsdk:"gid"`
+	Name              types.String `tfsdk:"name"`
+	ExpectedStatus    []int64      `tfsdk:"expected_status"`
+	Timeout           types.String `tfsdk:"timeout"`
+	OverrideOwnership types.Bool   `tfsdk:"override_ownership"`
+}
+
+// Metadata returns the action type name.
+func (a *verifyDestinationAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_verify_destination"
+}
+
+// Schema defines the schema for the action.
+func (a *verifyDestinationAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Requests the destination URL of a GoLink, following redirects, and fails when it does not respond as expected.",
+		Attributes: map[string]schema.Attribute{
+			"gid": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The ID of the GoLink to verify. Exactly one of `gid` or `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the GoLink to verify, without the provider `name_prefix`. Exactly one of `gid` or `name` must be set.",
+			},
+			"expected_status": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Description: "The HTTP status codes the destination may respond with. Any status below 400 is accepted when unset.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for the destination to respond, as a duration such as `30s`. Defaults to `10s`.",
+			},
+			"override_ownership": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the link may be verified even when its ownership marker names another Terraform workspace than the provider's `ownership_marker`.",
+			},
+		},
+	}
+}
+
+// Invoke requests the destination of the link.
+func (a *verifyDestinationAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config verifyDestinationActionModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Gid.IsNull() == config.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid GoLink Reference",
+			"Exactly one of `gid` or `name` must be set to identify the GoLink to verify.",
+		)
+		return
+	}
+
+	timeout := defaultVerifyDestinationTimeout
+	if !config.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(config.Timeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid Timeout",
+				fmt.Sprintf("The timeout must be a positive Go duration such as \"30s\", got %q.", config.Timeout.ValueString()),
+			)
+			return
+		}
+	}
+
+	golink := lookupActionLink(ctx, a.client, a.settings, config.Gid, config.Name, config.OverrideOwnership.ValueBool(), "verify", &resp.Diagnostics)
+	if golink == nil {
+		return
+	}
+
+	if IntToBool(golink.VariableLink) || strings.Contains(golink.URL, variableLinkArgument) || strings.Contains(golink.URL, variableLinkRemainder) {
+		resp.Diagnostics.AddError(
+			"Cannot Verify Variable Link",
+			fmt.Sprintf("Link %q is a variable link whose destination %s depends on its arguments, so it cannot be verified.", golink.Name, golink.URL),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Requesting %s for go/%s", golink.URL, golink.Name),
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	destReq, err := http.NewRequestWithContext(ctx, "GET", golink.URL, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Destination URL",
+			fmt.Sprintf("Link %q points to %q, which is not a valid URL: %s", golink.Name, golink.URL, err.Error()),
+		)
+		return
+	}
+
+	destResp, err := http.DefaultClient.Do(destReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Destination Unreachable",
+			fmt.Sprintf("Link %q points to %s, which could not be reached: %s", golink.Name, golink.URL, err.Error()),
+		)
+		return
+	}
+	destResp.Body.Close()
+
+	ok := destResp.StatusCode < 400
+	if len(config.ExpectedStatus) > 0 {
+		ok = slices.Contains(config.ExpectedStatus, int64(destResp.StatusCode))
+	}
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Destination Status",
+			fmt.Sprintf("Link %q points to %s, which responded with status %d.", golink.Name, golink.URL, destResp.StatusCode),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("go/%s responded with status %d", golink.Name, destResp.StatusCode),
+	})
+}
+
+// Configure adds the provider configured client to the action.
+func (a *verifyDestinationAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = data.Client
+	a.settings = data.Settings
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-golinks/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestVerifyDestinationAction(t *testing.T) {
+	destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(destination.Close)
+
+	server := newTestServer(t)
+	ok := server.addLink(client.GolinkResponse{Name: "ok", URL: destination.URL + "/ok"})
+	server.addLink(client.GolinkResponse{Name: "gone", URL: destination.URL + "/gone"})
+	server.addLink(client.GolinkResponse{Name: "search", URL: destination.URL + "/search?q=%s", VariableLink: 1})
+	a := &verifyDestinationAction{client: server.client(t)}
+
+	resp, progress := invokeAction(t, a, map[string]tftypes.Value{
+		"gid": tftypes.NewValue(tftypes.Number, ok.Gid),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(progress) != 2 {
+		t.Errorf("expected two progress messages, got %v", progress)
+	}
+
+	resp, _ = invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "gone"),
+		"expected_status": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{
+			tftypes.NewValue(tftypes.Number, 404),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	for _, attrs := range []map[string]tftypes.Value{
+		{"name": tftypes.NewValue(tftypes.String, "gone")},
+		{"name": tftypes.NewValue(tftypes.String, "search")},
+		{"name": tftypes.NewValue(tftypes.String, "ok"), "timeout": tftypes.NewValue(tftypes.String, "soon")},
+	} {
+		if resp, _ := invokeAction(t, a, attrs); !resp.Diagnostics.HasError() {
+			t.Errorf("expected an error for configuration %v", attrs)
+		}
+	}
+}
+
+func TestVerifyDestinationActionNamespace(t *testing.T) {
+	destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(destination.Close)
+
+	server := newTestServer(t)
+	server.addLink(client.GolinkResponse{Name: "payments/wiki", URL: destination.URL})
+	sales := server.addLink(client.GolinkResponse{Name: "sales/wiki", URL: destination.URL})
+	a := &verifyDestinationAction{client: server.client(t), settings: providerSettings{NamePrefix: "payments/"}}
+
+	for _, attrs := range []map[string]tftypes.Value{
+		{"gid": tftypes.NewValue(tftypes.Number, sales.Gid)},
+		{"name": tftypes.NewValue(tftypes.String, "sales/wiki")},
+	} {
+		if resp, _ := invokeAction(t, a, attrs); !resp.Diagnostics.HasError() {
+			t.Errorf("expected an error for configuration %v", attrs)
+		}
+	}
+
+	resp, _ := invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "wiki"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestVerifyDestinationActionOwnership(t *testing.T) {
+	destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(destination.Close)
+
+	server := newTestServer(t)
+	server.addLink(client.GolinkResponse{
+		Name:        "wiki",
+		URL:         destination.URL,
+		Description: "Team wiki\n\nManaged by Terraform workspace sales",
+	})
+	a := &verifyDestinationAction{client: server.client(t), settings: providerSettings{
+		Ownership: linkOwnership{Workspace: "payments", Marker: ownershipMarkerDescription},
+	}}
+
+	resp, _ := invokeAction(t, a, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "wiki"),
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a link owned by another workspace")
+	}
+
+	resp, _ = invokeAction(t, a, map[string]tftypes.Value{
+		"name":               tftypes.NewValue(tftypes.String, "wiki"),
+		"override_ownership": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}